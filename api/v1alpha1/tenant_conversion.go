@@ -0,0 +1,135 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+)
+
+var _ conversion.Convertible = &Tenant{}
+
+// ConvertTo converts this Tenant to the v1alpha3 hub, restoring the fields
+// v1alpha1 can't represent if it was converted from the hub.
+func (src *Tenant) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha3.Tenant)
+	restored := &v1alpha3.Tenant{}
+	if _, err := v1alpha3.UnmarshalConversionData(src, restored); err != nil {
+		return err
+	}
+	src.convertTo(dst, restored)
+	return nil
+}
+
+// ConvertFrom converts the v1alpha3 hub to this Tenant, keeping the fields
+// v1alpha1 can't represent in an annotation.
+func (dst *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha3.Tenant)
+	src = src.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = TenantSpec{
+		TenantName:      src.Spec.TenantName,
+		State:           src.Spec.State,
+		ChildNamespaces: src.Spec.ChildNamespaces,
+		TenantResources: convertResourcesFrom(src.Spec.TenantResources),
+	}
+	dst.Status = TenantStatus{
+		ChildNamespaces: src.Status.ChildNamespaces,
+		TenantResources: convertResourcesFrom(src.Status.TenantResources),
+		UUID:            src.Status.UUID,
+	}
+
+	converted := &v1alpha3.Tenant{}
+	dst.convertTo(converted, &v1alpha3.Tenant{})
+	if equality.Semantic.DeepEqual(converted.Spec, src.Spec) && equality.Semantic.DeepEqual(converted.Status, src.Status) {
+		return nil
+	}
+	return v1alpha3.MarshalConversionData(src, dst)
+}
+
+// convertTo converts this Tenant to dst, starting from the spec and status
+// in restored.
+func (src *Tenant) convertTo(dst *v1alpha3.Tenant, restored *v1alpha3.Tenant) {
+	src = src.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	v1alpha3.RemoveConversionData(dst)
+
+	dst.Spec = restored.Spec
+	dst.Spec.TenantName = src.Spec.TenantName
+	dst.Spec.State = src.Spec.State
+	dst.Spec.ChildNamespaces = src.Spec.ChildNamespaces
+	dst.Spec.TenantResources = convertResourcesTo(src.Spec.TenantResources, restored.Spec.TenantResources)
+
+	dst.Status = restored.Status
+	dst.Status.ChildNamespaces = src.Status.ChildNamespaces
+	dst.Status.TenantResources = convertResourcesTo(src.Status.TenantResources, restored.Status.TenantResources)
+	dst.Status.UUID = src.Status.UUID
+}
+
+// convertResourcesTo converts resources to v1alpha3, keeping the fields of
+// the restored resource of the same type. v1alpha1 did not manage power, so
+// resources without a restored power policy get none.
+func convertResourcesTo(resources []TenantResource, restored []v1alpha3.TenantResource) []v1alpha3.TenantResource {
+	if resources == nil {
+		return nil
+	}
+	converted := make([]v1alpha3.TenantResource, len(resources))
+	for i, resource := range resources {
+		for _, r := range restored {
+			if r.Type == resource.Type {
+				converted[i] = r
+				break
+			}
+		}
+		converted[i].Type = resource.Type
+		converted[i].Xnames = resource.Xnames
+		converted[i].HsmPartitionName = resource.HsmPartitionName
+		converted[i].HsmGroupLabel = resource.HsmGroupLabel
+		converted[i].EnforceExclusiveHsmGroups = resource.EnforceExclusiveHsmGroups
+	}
+	return converted
+}
+
+// convertResourcesFrom converts v1alpha3 resources to v1alpha1.
+func convertResourcesFrom(resources []v1alpha3.TenantResource) []TenantResource {
+	if resources == nil {
+		return nil
+	}
+	converted := make([]TenantResource, len(resources))
+	for i, resource := range resources {
+		converted[i] = TenantResource{
+			Type:                      resource.Type,
+			Xnames:                    resource.Xnames,
+			HsmPartitionName:          resource.HsmPartitionName,
+			HsmGroupLabel:             resource.HsmGroupLabel,
+			EnforceExclusiveHsmGroups: resource.EnforceExclusiveHsmGroups,
+		}
+	}
+	return converted
+}
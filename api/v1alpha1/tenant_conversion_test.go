@@ -0,0 +1,126 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+)
+
+// newFuzzer returns a fuzzer whose times survive a JSON round trip.
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(0.2).Funcs(
+		func(t *metav1.Time, c fuzz.Continue) {
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+	)
+}
+
+func TestTenantConversionRoundTrip(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		f := newFuzzer(seed)
+
+		hub := &v1alpha3.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"}}
+		f.Fuzz(&hub.Spec)
+		f.Fuzz(&hub.Status)
+		for i := range hub.Spec.TenantResources {
+			hub.Spec.TenantResources[i].Type = fmt.Sprintf("type-%d", i)
+		}
+		for i := range hub.Status.TenantResources {
+			hub.Status.TenantResources[i].Type = fmt.Sprintf("type-%d", i)
+		}
+		spoke := &Tenant{}
+		if err := spoke.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(spoke)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spoke = &Tenant{}
+		if err := json.Unmarshal(data, spoke); err != nil {
+			t.Fatal(err)
+		}
+		converted := &v1alpha3.Tenant{}
+		if err := spoke.ConvertTo(converted); err != nil {
+			t.Fatal(err)
+		}
+		if !equality.Semantic.DeepEqual(hub, converted) {
+			t.Fatalf("seed %d: hub round trip changed the tenant\nfrom %+v\nto   %+v", seed, hub, converted)
+		}
+
+		spoke = &Tenant{ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"}}
+		f.Fuzz(&spoke.Spec)
+		f.Fuzz(&spoke.Status)
+		hub = &v1alpha3.Tenant{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatal(err)
+		}
+		roundTripped := &Tenant{}
+		if err := roundTripped.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		if !equality.Semantic.DeepEqual(spoke, roundTripped) {
+			t.Fatalf("seed %d: spoke round trip changed the tenant\nfrom %+v\nto   %+v", seed, spoke, roundTripped)
+		}
+	}
+}
+
+func TestTenantConversionKms(t *testing.T) {
+	hub := &v1alpha3.Tenant{
+		Spec: v1alpha3.TenantSpec{
+			TenantName:        "vcluster-blue",
+			TenantResources:   []v1alpha3.TenantResource{{Type: "compute", Xnames: []string{"x0c3s5b0n0"}, PowerPolicy: v1alpha3.PowerPolicyOff}},
+			TenantKmsResource: v1alpha3.TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-3072"},
+		},
+	}
+	spoke := &Tenant{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spoke.Annotations[v1alpha3.TenantConversionDataAnnotation]; !ok {
+		t.Fatal("expected the fields v1alpha1 lacks to be stored in an annotation")
+	}
+
+	// A v1alpha1 client changing the xnames keeps the KMS and power settings.
+	spoke.Spec.TenantResources[0].Xnames = []string{"x0c3s6b0n0"}
+	converted := &v1alpha3.Tenant{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	hub.Spec.TenantResources[0].Xnames = []string{"x0c3s6b0n0"}
+	if !equality.Semantic.DeepEqual(hub, converted) {
+		t.Errorf("expected %+v, got %+v", hub, converted)
+	}
+}
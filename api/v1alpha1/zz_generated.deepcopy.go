@@ -22,151 +22,9 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/runtime"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmComponent) DeepCopyInto(out *HsmComponent) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmComponent.
-func (in *HsmComponent) DeepCopy() *HsmComponent {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmComponent)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmComponentList) DeepCopyInto(out *HsmComponentList) {
-	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make([]HsmComponent, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmComponentList.
-func (in *HsmComponentList) DeepCopy() *HsmComponentList {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmComponentList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmGroup) DeepCopyInto(out *HsmGroup) {
-	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.Members.DeepCopyInto(&out.Members)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmGroup.
-func (in *HsmGroup) DeepCopy() *HsmGroup {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmGroup)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmIds) DeepCopyInto(out *HsmIds) {
-	*out = *in
-	if in.Ids != nil {
-		in, out := &in.Ids, &out.Ids
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmIds.
-func (in *HsmIds) DeepCopy() *HsmIds {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmIds)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmMemberId) DeepCopyInto(out *HsmMemberId) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmMemberId.
-func (in *HsmMemberId) DeepCopy() *HsmMemberId {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmMemberId)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmPartition) DeepCopyInto(out *HsmPartition) {
-	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.Members.DeepCopyInto(&out.Members)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmPartition.
-func (in *HsmPartition) DeepCopy() *HsmPartition {
-	if in == nil {
-		return nil
-	}
-	out := new(HsmPartition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakGroup) DeepCopyInto(out *KeycloakGroup) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakGroup.
-func (in *KeycloakGroup) DeepCopy() *KeycloakGroup {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakGroup)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRole) DeepCopyInto(out *KeycloakRole) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRole.
-func (in *KeycloakRole) DeepCopy() *KeycloakRole {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRole)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
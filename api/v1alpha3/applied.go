@@ -0,0 +1,171 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+)
+
+// appliedStatus returns the namespaces and HSM members applied for the
+// tenant. For a tenant last reconciled before these were recorded, they
+// are derived from the child namespaces and resources it was last
+// deployed with.
+func (t *Tenant) appliedStatus() *TenantAppliedStatus {
+	if t.Status.Applied != nil {
+		return t.Status.Applied
+	}
+	return &TenantAppliedStatus{
+		ChildNamespaces: append([]string(nil), t.Status.ChildNamespaces...),
+		HsmPartitions:   sortedHsmMembers(hsmMembersByName(t.Status.TenantResources, func(r TenantResource) string { return r.HsmPartitionName })),
+		HsmGroups:       sortedHsmMembers(hsmMembersByName(t.Status.TenantResources, func(r TenantResource) string { return r.HsmGroupLabel })),
+	}
+}
+
+// recordApplied returns Status.Applied, initializing it first if needed.
+func (t *Tenant) recordApplied() *TenantAppliedStatus {
+	if t.Status.Applied == nil {
+		t.Status.Applied = t.appliedStatus()
+	}
+	return t.Status.Applied
+}
+
+// appliedChildNamespaces returns the child namespaces created for the
+// tenant, as named in the spec.
+func (t *Tenant) appliedChildNamespaces() []string {
+	var childNamespaces []string
+	for _, childNs := range t.appliedStatus().ChildNamespaces {
+		childNamespaces = append(childNamespaces, strings.TrimPrefix(childNs, t.Spec.TenantName+"-"))
+	}
+	return childNamespaces
+}
+
+// SetChildNamespaceApplied records that a child namespace of the tenant
+// was created, or deleted if applied is false.
+func (t *Tenant) SetChildNamespaceApplied(childNs string, applied bool) {
+	a := t.recordApplied()
+	if !applied {
+		a.ChildNamespaces = Difference(a.ChildNamespaces, []string{childNs})
+	} else if !Contains(a.ChildNamespaces, childNs) {
+		a.ChildNamespaces = append(a.ChildNamespaces, childNs)
+	}
+}
+
+func (a *TenantAppliedStatus) hsmMembers(kind string) *[]TenantHsmMembers {
+	if kind == HsmKindPartition {
+		return &a.HsmPartitions
+	}
+	return &a.HsmGroups
+}
+
+// appliedHsmMembers returns the members applied to an HSM partition or
+// group, and false if none were ever applied.
+func (t *Tenant) appliedHsmMembers(kind string, name string) ([]string, bool) {
+	for _, members := range *t.appliedStatus().hsmMembers(kind) {
+		if members.Name == name {
+			return members.Xnames, true
+		}
+	}
+	return nil, false
+}
+
+func (t *Tenant) setAppliedHsmMembers(kind string, name string, xnames []string) {
+	list := t.recordApplied().hsmMembers(kind)
+	for i := range *list {
+		if (*list)[i].Name == name {
+			(*list)[i].Xnames = xnames
+			return
+		}
+	}
+	*list = append(*list, TenantHsmMembers{Name: name, Xnames: xnames})
+}
+
+func (t *Tenant) removeAppliedHsmMembers(kind string, name string) {
+	list := t.recordApplied().hsmMembers(kind)
+	for i := range *list {
+		if (*list)[i].Name == name {
+			*list = append((*list)[:i], (*list)[i+1:]...)
+			return
+		}
+	}
+}
+
+// applyMemberReport updates the applied members of an HSM partition or
+// group with the xnames a member edit added or removed, or that HSM
+// reported already were. Xnames that failed, e.g. because HSM rejected
+// adding an xname in another partition, are left as they were so they
+// are tried again.
+func (t *Tenant) applyMemberReport(kind string, name string, report hsm.MemberReport, httpMethod string) {
+	if len(report) == 0 {
+		return
+	}
+	members, _ := t.appliedHsmMembers(kind, name)
+	members = append([]string(nil), members...)
+	if httpMethod == http.MethodPost {
+		members = appendUnique(members, report.Applied())
+	} else {
+		members = Difference(members, report.Applied())
+	}
+	t.setAppliedHsmMembers(kind, name, members)
+}
+
+// hsmMemberChanges returns the members to add to and delete from an HSM
+// partition or group, comparing the resolved xnames in the spec with the
+// members applied to it.
+func hsmMemberChanges(t *Tenant, kind string, name string) (addedMembers []string, deletedMembers []string) {
+	resourceName := func(r TenantResource) string { return r.HsmPartitionName }
+	if kind == HsmKindGroup {
+		resourceName = func(r TenantResource) string { return r.HsmGroupLabel }
+	}
+	desired := hsmMembersByName(t.ResolvedResources(), resourceName)[name]
+	applied, _ := t.appliedHsmMembers(kind, name)
+	return Difference(desired, applied), Difference(applied, desired)
+}
+
+// hsmMembersByName returns the xnames of the resources for each HSM
+// partition or group, as named by name for each resource.
+func hsmMembersByName(resources []TenantResource, name func(TenantResource) string) map[string][]string {
+	members := map[string][]string{}
+	for _, resource := range resources {
+		if len(name(resource)) > 0 {
+			members[name(resource)] = appendUnique(members[name(resource)], resource.Xnames)
+		}
+	}
+	return members
+}
+
+func sortedHsmMembers(byName map[string][]string) []TenantHsmMembers {
+	var members []TenantHsmMembers
+	for name, xnames := range byName {
+		members = append(members, TenantHsmMembers{Name: name, Xnames: xnames})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members
+}
@@ -0,0 +1,61 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+)
+
+func TestApplyMemberReport(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.Status.TenantResources = []TenantResource{{Type: "compute", Xnames: []string{"x1", "x2"}, HsmPartitionName: "blue"}}
+
+	tenant.applyMemberReport(HsmKindPartition, "blue", hsm.MemberReport{
+		{Xname: "x2", Status: hsm.MemberChanged},
+	}, http.MethodDelete)
+	tenant.applyMemberReport(HsmKindPartition, "blue", hsm.MemberReport{
+		{Xname: "x3", Status: hsm.MemberChanged},
+		{Xname: "x4", Status: hsm.MemberUnchanged},
+		{Xname: "x5", Status: hsm.MemberFailed, Err: errors.New("failed")},
+	}, http.MethodPost)
+
+	members, applied := tenant.appliedHsmMembers(HsmKindPartition, "blue")
+	if !applied || !reflect.DeepEqual(members, []string{"x1", "x3", "x4"}) {
+		t.Errorf("unexpected applied partition members %v", members)
+	}
+	if !reflect.DeepEqual(tenant.Status.TenantResources[0].Xnames, []string{"x1", "x2"}) {
+		t.Errorf("expected the last deployed resources to be unchanged, got %v", tenant.Status.TenantResources[0].Xnames)
+	}
+	if _, applied := tenant.appliedHsmMembers(HsmKindGroup, "blue"); applied {
+		t.Error("expected no applied HSM group")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/metrics"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/operatorconfig"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
+)
+
+// Circuit breakers for the backends reached through the API gateway, shared
+// by every client of the backend. After five consecutive transient failures,
+// calls fail fast for 30 seconds rather than adding load to a struggling
+// service.
+var (
+	hsmBreaker      = retry.NewBreaker(metrics.ServiceHsm, 5, 30*time.Second)
+	pcsBreaker      = retry.NewBreaker(metrics.ServicePcs, 5, 30*time.Second)
+	keycloakBreaker = retry.NewBreaker(metrics.ServiceKeycloak, 5, 30*time.Second)
+	vaultBreaker    = retry.NewBreaker(metrics.ServiceVault, 5, 30*time.Second)
+)
+
+// The HTTP clients below retry transient failures and record metrics for
+// every attempt.
+
+// Idle connections are kept for each parallel request, so bulk member
+// changes reuse connections rather than opening new ones.
+func newHsmHttpClient(service operatorconfig.Hsm) (*http.Client, error) {
+	client, err := newServiceHttpClient(service.Service)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport.(*http.Transport).MaxIdleConnsPerHost = service.MaxParallelRequests
+	client = metrics.InstrumentClient(client, metrics.ServiceHsm, hsmOperation)
+	return retry.WrapClient(client, retry.DefaultBackoff, hsmBreaker), nil
+}
+
+func newPcsHttpClient(service operatorconfig.Service) (*http.Client, error) {
+	client, err := newServiceHttpClient(service)
+	if err != nil {
+		return nil, err
+	}
+	client = metrics.InstrumentClient(client, metrics.ServicePcs, operationFor(pcsOperations))
+	return retry.WrapClient(client, retry.DefaultBackoff, pcsBreaker), nil
+}
+
+func newKeycloakHttpClient(service operatorconfig.Service) (*http.Client, error) {
+	client, err := newServiceHttpClient(service)
+	if err != nil {
+		return nil, err
+	}
+	client = metrics.InstrumentClient(client, metrics.ServiceKeycloak, operationFor(keycloakOperations))
+	return retry.WrapClient(client, retry.DefaultBackoff, keycloakBreaker), nil
+}
+
+// The Vault API client retries on its own, so only the breaker is added.
+func newVaultHttpClient(client *http.Client) *http.Client {
+	client = metrics.InstrumentClient(client, metrics.ServiceVault, operationFor(vaultOperations))
+	return retry.WrapClient(client, retry.Backoff{Steps: 1}, vaultBreaker)
+}
+
+// Hook requests are labeled with the tenant event, see CallHook. Hooks
+// are not retried, since a hook may not be idempotent, and each request is
+// limited to HookTimeout. Hooks without a secret keep skipping server
+// certificate verification, otherwise tlsConfig is used.
+func newHookHttpClient(tlsConfig *tls.Config) *http.Client {
+	httpClient := NewHttpClient()
+	httpClient.Timeout = HookTimeout
+	if tlsConfig != nil {
+		httpClient.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	}
+	return metrics.InstrumentClient(httpClient, metrics.ServiceHook, nil)
+}
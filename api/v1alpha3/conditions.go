@@ -0,0 +1,90 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Tenant lifecycle phases recorded in Status.Phase.
+const (
+	PhaseNew       = "New"
+	PhaseDeploying = "Deploying"
+	PhaseDeployed  = "Deployed"
+	PhaseDeleting  = "Deleting"
+	// A provisioning step failed with an error that retrying is unlikely
+	// to fix, e.g. a request rejected by HSM.
+	PhaseFailed = "Failed"
+)
+
+// Tenant condition types, one per provisioning step, plus an overall
+// Ready condition summarizing them.
+const (
+	ConditionReady             = "Ready"
+	ConditionXnamesReady       = "XnamesReady"
+	ConditionNamespacesReady   = "NamespacesReady"
+	ConditionHsmPartitionReady = "HsmPartitionReady"
+	ConditionHsmGroupReady     = "HsmGroupReady"
+	ConditionPowerReady        = "PowerReady"
+	ConditionKeycloakReady     = "KeycloakReady"
+	ConditionVaultKmsReady     = "VaultKmsReady"
+	ConditionHooksDelivered    = "HooksDelivered"
+	ConditionHsmInSync         = "HsmInSync"
+)
+
+// Condition reasons.
+const (
+	ReasonSucceeded     = "Succeeded"
+	ReasonFailed        = "Failed"
+	ReasonPending       = "Pending"
+	ReasonNotRequired   = "NotRequired"
+	ReasonDeleting      = "Deleting"
+	ReasonDriftDetected = "DriftDetected"
+	ReasonDriftRepaired = "DriftRepaired"
+	// The backend resources created for a tenant that failed to provision
+	// are being deleted, or have been, per its failure policy.
+	ReasonRollingBack = "RollingBack"
+	ReasonRolledBack  = "RolledBack"
+)
+
+// SetCondition records the outcome of a provisioning step on the
+// tenant status. The transition time only changes when the status does.
+func (t *Tenant) SetCondition(conditionType string, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&t.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: t.Generation,
+	})
+}
+
+// GetCondition returns the condition of the given type, or nil.
+func (t *Tenant) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(t.Status.Conditions, conditionType)
+}
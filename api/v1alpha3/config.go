@@ -0,0 +1,86 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/operatorconfig"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/pcs"
+)
+
+// configMu guards the operator config and the clients built from it,
+// which are replaced when the config file changes.
+var (
+	configMu       sync.RWMutex
+	operatorConfig *operatorconfig.Config
+	keycloakHttp   *http.Client
+)
+
+// Configure points the HSM, PCS, Keycloak and Vault clients at the
+// services in cfg. It must be called at startup, before the clients are
+// used, and is called again whenever the config file changes. Tokens are
+// fetched again after a change.
+func Configure(cfg *operatorconfig.Config) error {
+	hsmHttp, err := newHsmHttpClient(cfg.Hsm)
+	if err != nil {
+		return err
+	}
+	pcsHttp, err := newPcsHttpClient(cfg.Pcs)
+	if err != nil {
+		return err
+	}
+	keycloakHttpClient, err := newKeycloakHttpClient(cfg.Keycloak.Service)
+	if err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	operatorConfig = cfg
+	HsmClient = hsm.NewClient(cfg.Hsm.URL, hsmHttp, gatewayToken)
+	PcsClient = pcs.NewClient(cfg.Pcs.URL, pcsHttp, gatewayToken)
+	keycloakHttp = keycloakHttpClient
+	masterTokens = newTokenCache(cfg.Keycloak.URL, cfg.Keycloak.MasterRealm, keycloakHttpClient, getMasterTokenUrlValues)
+	clusterTokens = newTokenCache(cfg.Keycloak.GatewayURL, cfg.Keycloak.Realm, keycloakHttpClient, getTokenUrlValues)
+	return nil
+}
+
+func currentConfig() *operatorconfig.Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return operatorConfig
+}
+
+// KeycloakHttpClient returns the client for Keycloak requests.
+func KeycloakHttpClient() *http.Client {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return keycloakHttp
+}
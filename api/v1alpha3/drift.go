@@ -0,0 +1,186 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// Drift policies for a tenant.
+const (
+	DriftPolicyReport  = "report"
+	DriftPolicyEnforce = "enforce"
+)
+
+// Kinds of HSM objects checked for drift.
+const (
+	HsmKindPartition = "partition"
+	HsmKindGroup     = "group"
+)
+
+// HsmDrift is the difference between the members of an HSM partition or
+// group and the tenant spec.
+type HsmDrift struct {
+	Kind string
+	// The HSM partition name or group label.
+	Name string
+	// True if the partition or group does not exist in HSM.
+	NotFound bool
+	// Xnames in the spec that are not members in HSM.
+	Missing []string
+	// Members in HSM that are not xnames in the spec.
+	Unexpected []string
+}
+
+// Count returns the number of xnames that have drifted.
+func (d HsmDrift) Count() int {
+	return len(d.Missing) + len(d.Unexpected)
+}
+
+func (d HsmDrift) String() string {
+	if d.NotFound {
+		return fmt.Sprintf("HSM %s %s not found", d.Kind, d.Name)
+	}
+	return fmt.Sprintf("HSM %s %s missing %v, unexpected %v", d.Kind, d.Name, d.Missing, d.Unexpected)
+}
+
+// DetectHSMDrift compares the membership of the tenant's HSM partitions and
+// groups, as read from HSM, with the xnames in the tenant spec. Only
+// partitions and groups with drift are returned.
+func DetectHSMDrift(ctx context.Context, log logr.Logger, t *Tenant) ([]HsmDrift, error) {
+	var drift []HsmDrift
+
+	expected := expectedHsmMembers(t, func(r TenantResource) string { return r.HsmPartitionName })
+	if len(expected) > 0 {
+		_, partitionList, err := ListHSMPartitions(ctx, log)
+		if err != nil {
+			return nil, err
+		}
+		actual := map[string][]string{}
+		for _, partition := range partitionList {
+			actual[partition.Name] = partition.Members.Ids
+		}
+		drift = append(drift, compareHsmMembers(HsmKindPartition, expected, actual)...)
+	}
+
+	expected = expectedHsmMembers(t, func(r TenantResource) string { return r.HsmGroupLabel })
+	if len(expected) > 0 {
+		_, groupList, err := ListHSMGroups(ctx, log)
+		if err != nil {
+			return nil, err
+		}
+		actual := map[string][]string{}
+		for _, group := range groupList {
+			actual[group.Label] = group.Members.Ids
+		}
+		drift = append(drift, compareHsmMembers(HsmKindGroup, expected, actual)...)
+	}
+
+	return drift, nil
+}
+
+// RepairHSMDrift makes the HSM partitions and groups match the tenant spec.
+func RepairHSMDrift(ctx context.Context, log logr.Logger, t *Tenant, drift []HsmDrift) error {
+	for _, d := range drift {
+		log.Info("Repairing drift: " + d.String())
+		resource := findHsmResource(t, d)
+		var err error
+		switch {
+		case d.Kind == HsmKindPartition && d.NotFound:
+			_, err = createHSMPartition(ctx, log, t.Name, d.Name, d.Missing)
+		case d.Kind == HsmKindPartition:
+			if _, _, err = editHsmPartitionMembers(ctx, log, d.Name, d.Unexpected, http.MethodDelete); err == nil {
+				_, _, err = editHsmPartitionMembers(ctx, log, d.Name, d.Missing, http.MethodPost)
+			}
+		case d.Kind == HsmKindGroup && d.NotFound:
+			_, err = createHSMGroup(ctx, log, t.Name, d.Name, d.Missing, resource.EnforceExclusiveHsmGroups)
+		case d.Kind == HsmKindGroup:
+			if _, _, err = editHsmGroupMembers(ctx, log, d.Name, d.Unexpected, http.MethodDelete); err == nil {
+				_, _, err = editHsmGroupMembers(ctx, log, d.Name, d.Missing, http.MethodPost)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to repair drift of HSM %s %s: %w", d.Kind, d.Name, err)
+		}
+	}
+	return nil
+}
+
+// DriftSummary describes drift for a condition message or event.
+func DriftSummary(drift []HsmDrift) string {
+	summaries := make([]string, 0, len(drift))
+	for _, d := range drift {
+		summaries = append(summaries, d.String())
+	}
+	return strings.Join(summaries, "; ")
+}
+
+// expectedHsmMembers returns the resolved xnames in the spec for each HSM
+// partition or group, as named by name for each resource.
+func expectedHsmMembers(t *Tenant, name func(TenantResource) string) map[string][]string {
+	return hsmMembersByName(t.ResolvedResources(), name)
+}
+
+func compareHsmMembers(kind string, expected map[string][]string, actual map[string][]string) []HsmDrift {
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var drift []HsmDrift
+	for _, name := range names {
+		xnames := expected[name]
+		members, found := actual[name]
+		d := HsmDrift{
+			Kind:       kind,
+			Name:       name,
+			NotFound:   !found,
+			Missing:    Difference(xnames, members),
+			Unexpected: Difference(members, xnames),
+		}
+		if d.NotFound || d.Count() > 0 {
+			drift = append(drift, d)
+		}
+	}
+	return drift
+}
+
+func findHsmResource(t *Tenant, d HsmDrift) TenantResource {
+	for _, resource := range t.ResolvedResources() {
+		if (d.Kind == HsmKindPartition && resource.HsmPartitionName == d.Name) || (d.Kind == HsmKindGroup && resource.HsmGroupLabel == d.Name) {
+			return resource
+		}
+	}
+	return TenantResource{}
+}
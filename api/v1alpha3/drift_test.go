@@ -0,0 +1,53 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareHsmMembers(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.Spec.TenantResources = []TenantResource{
+		{Type: "compute", Xnames: []string{"x1", "x2"}, HsmPartitionName: "blue", HsmGroupLabel: "blue"},
+		{Type: "application", Xnames: []string{"x3"}, HsmPartitionName: "blue", HsmGroupLabel: "blue-uan"},
+	}
+
+	partitions := expectedHsmMembers(tenant, func(r TenantResource) string { return r.HsmPartitionName })
+	drift := compareHsmMembers(HsmKindPartition, partitions, map[string][]string{"blue": {"x1", "x3", "x4"}})
+	expected := []HsmDrift{{Kind: HsmKindPartition, Name: "blue", Missing: []string{"x2"}, Unexpected: []string{"x4"}}}
+	if !reflect.DeepEqual(drift, expected) {
+		t.Errorf("expected %+v, got %+v", expected, drift)
+	}
+
+	groups := expectedHsmMembers(tenant, func(r TenantResource) string { return r.HsmGroupLabel })
+	drift = compareHsmMembers(HsmKindGroup, groups, map[string][]string{"blue": {"x2", "x1"}})
+	if len(drift) != 1 || drift[0].Name != "blue-uan" || !drift[0].NotFound || drift[0].Count() != 1 {
+		t.Errorf("expected only the missing blue-uan group, got %+v", drift)
+	}
+}
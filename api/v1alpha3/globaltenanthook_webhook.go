@@ -0,0 +1,189 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// GlobalTenantHookPingAnnotation asks the webhook to send a PING event to a
+// global hook, rejecting the change if the hook can't be reached. The ping
+// is sent when the annotation is added or its value changes, so setting it
+// to a timestamp tests a hook without changing its spec. No ping is sent
+// for dry-run requests, as the webhook declares sideEffects=NoneOnDryRun.
+const GlobalTenantHookPingAnnotation = "tapms.hpe.com/ping"
+
+// EventPing is the event type of a ping. Hooks can't subscribe to it, it is
+// only sent on request.
+const EventPing = "PING"
+
+// The path of the GlobalTenantHook validating webhook.
+const globalTenantHookValidatePath = "/validate-tapms-hpe-com-v1alpha3-globaltenanthook"
+
+func (h *GlobalTenantHook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(globalTenantHookValidatePath, &webhook.Admission{Handler: &globalTenantHookValidator{}})
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-tapms-hpe-com-v1alpha3-globaltenanthook,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=tapms.hpe.com,resources=globaltenanthooks,verbs=create;update,versions=v1alpha3,name=vglobaltenanthook.kb.io,admissionReviewVersions=v1
+
+// globalTenantHookValidator validates GlobalTenantHooks. It handles the
+// admission request itself, rather than GlobalTenantHook implementing
+// webhook.Validator, as it needs to know whether the request is a dry run.
+type globalTenantHookValidator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &globalTenantHookValidator{}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (v *globalTenantHookValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle validates created and updated GlobalTenantHooks.
+func (v *globalTenantHookValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	hook := &GlobalTenantHook{}
+	if err := v.decoder.Decode(req, hook); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var old *GlobalTenantHook
+	if req.Operation == admissionv1.Update {
+		old = &GlobalTenantHook{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	Log.Info(fmt.Sprintf("Validating %s for", strings.ToLower(string(req.Operation))), "globaltenanthook", hook.Name)
+	dryRun := req.DryRun != nil && *req.DryRun
+	if err := hook.validate(ctx, old, dryRun); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validate checks the hook would be called successfully for tenant events,
+// so a bad global hook is rejected here rather than failing every tenant
+// operation. The old hook is only set for updates. The hook is not pinged
+// for a dry run.
+func (h *GlobalTenantHook) validate(ctx context.Context, old *GlobalTenantHook, dryRun bool) error {
+	if h.Namespace != globalHooksNamespace {
+		return fmt.Errorf("global tenant hooks must be in the %s namespace, hooks in %s are never called", globalHooksNamespace, h.Namespace)
+	}
+	if h.Spec.Name == "" {
+		return fmt.Errorf("global tenant hook %s must have a name", h.Name)
+	}
+	if err := validateEventType(Log, h.Spec.EventTypes); err != nil {
+		return err
+	}
+	if err := validateHookUrl(h.Spec.Url); err != nil {
+		return err
+	}
+	if h.Spec.TenantSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(h.Spec.TenantSelector); err != nil {
+			return fmt.Errorf("invalid tenant selector: %w", err)
+		}
+	}
+	for _, field := range h.Spec.ChangedFields {
+		if _, ok := changedFieldValues[field]; !ok {
+			return fmt.Errorf("changed field '%s' is not supported, must be one of: %v", field, changedFieldNames())
+		}
+	}
+	if h.Spec.SecretName != "" {
+		if _, err := getHookCredentials(ctx, h.Namespace, h.Spec.SecretName); err != nil {
+			return err
+		}
+	}
+
+	var hooks GlobalTenantHookList
+	if err := HooksClient.List(ctx, &hooks, client.InNamespace(h.Namespace)); err != nil {
+		return fmt.Errorf("failed to get global tenant webhooks list: %w", err)
+	}
+	for _, other := range hooks.Items {
+		if other.Name != h.Name && other.Spec.Name == h.Spec.Name {
+			return fmt.Errorf("hook name '%s' is already used by global tenant hook %s", h.Spec.Name, other.Name)
+		}
+	}
+
+	if ping, ok := h.Annotations[GlobalTenantHookPingAnnotation]; ok && !dryRun && (old == nil || old.Annotations[GlobalTenantHookPingAnnotation] != ping) {
+		return h.ping(ctx)
+	}
+	return nil
+}
+
+// ping sends a PING event to the hook, returning an error unless it
+// responds with a 2xx status.
+func (h *GlobalTenantHook) ping(ctx context.Context) error {
+	payload, err := json.Marshal(TenantEventPayload{
+		Version:   TenantEventPayloadVersion,
+		EventID:   uuid.New().String(),
+		EventType: EventPing,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	Log.Info(fmt.Sprintf("Pinging hook named '%s' at url %s", h.Spec.Name, h.Spec.Url))
+	if err := DeliverHook(ctx, h.Spec.TenantHook, h.Namespace, EventPing, payload); err != nil {
+		return fmt.Errorf("ping to '%s' hook at url %s failed: %w", h.Spec.Name, h.Spec.Url, err)
+	}
+	return nil
+}
+
+// validateHookUrl checks a hook URL is an absolute http or https URL.
+func validateHookUrl(hookUrl string) error {
+	u, err := url.Parse(hookUrl)
+	if err != nil {
+		return fmt.Errorf("invalid hook url %s: %w", hookUrl, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("hook url %s must use http or https", hookUrl)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("hook url %s must have a host", hookUrl)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestGlobalTenantHookValidate(t *testing.T) {
+	var pings []TenantEventPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload TenantEventPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		pings = append(pings, payload)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	HooksClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&GlobalTenantHook{
+		ObjectMeta: metav1.ObjectMeta{Name: "wlm", Namespace: "tenants"},
+		Spec:       GlobalTenantHookSpec{TenantHook: TenantHook{Name: "wlm", Url: "http://wlm", EventTypes: []string{"CREATE"}}},
+	}).Build()
+	defer func() { HooksClient = nil }()
+
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := &globalTenantHookValidator{decoder: decoder}
+	validate := func(hook *GlobalTenantHook, old *GlobalTenantHook, dryRun bool) admission.Response {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &dryRun}}
+		req.Object.Raw, _ = json.Marshal(hook)
+		if old != nil {
+			req.Operation = admissionv1.Update
+			req.OldObject.Raw, _ = json.Marshal(old)
+		}
+		return validator.Handle(context.Background(), req)
+	}
+
+	newHook := func(update func(*GlobalTenantHook)) *GlobalTenantHook {
+		hook := &GlobalTenantHook{
+			TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "GlobalTenantHook"},
+			ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "tenants"},
+			Spec:       GlobalTenantHookSpec{TenantHook: TenantHook{Name: "audit", Url: server.URL, EventTypes: []string{"CREATE", EventProvisioned}}},
+		}
+		update(hook)
+		return hook
+	}
+
+	tests := []struct {
+		name  string
+		hook  *GlobalTenantHook
+		valid bool
+	}{
+		{"valid", newHook(func(h *GlobalTenantHook) {}), true},
+		{"other namespace", newHook(func(h *GlobalTenantHook) { h.Namespace = "default" }), false},
+		{"unknown event type", newHook(func(h *GlobalTenantHook) { h.Spec.EventTypes = []string{"CREATED"} }), false},
+		{"relative url", newHook(func(h *GlobalTenantHook) { h.Spec.Url = "/hook" }), false},
+		{"ftp url", newHook(func(h *GlobalTenantHook) { h.Spec.Url = "ftp://audit/hook" }), false},
+		{"duplicate name", newHook(func(h *GlobalTenantHook) { h.Spec.Name = "wlm" }), false},
+		{"unknown changed field", newHook(func(h *GlobalTenantHook) { h.Spec.ChangedFields = []string{"state"} }), false},
+		{"missing secret", newHook(func(h *GlobalTenantHook) { h.Spec.SecretName = "missing" }), false},
+		{"unreachable ping", newHook(func(h *GlobalTenantHook) {
+			h.Spec.Url = "http://127.0.0.1:1"
+			h.Annotations = map[string]string{GlobalTenantHookPingAnnotation: "1"}
+		}), false},
+	}
+	for _, test := range tests {
+		resp := validate(test.hook, nil, false)
+		if test.valid && !resp.Allowed {
+			t.Errorf("%s: expected the hook to be valid, got %v", test.name, resp.Result)
+		}
+		if !test.valid && resp.Allowed {
+			t.Errorf("%s: expected the hook to be rejected", test.name)
+		}
+	}
+
+	hook := newHook(func(h *GlobalTenantHook) { h.Annotations = map[string]string{GlobalTenantHookPingAnnotation: "1"} })
+	if resp := validate(hook, hook.DeepCopy(), false); !resp.Allowed || len(pings) != 0 {
+		t.Errorf("expected no ping for an unchanged annotation, got %v and %d pings", resp.Result, len(pings))
+	}
+	if resp := validate(hook, newHook(func(h *GlobalTenantHook) {}), true); !resp.Allowed || len(pings) != 0 {
+		t.Errorf("expected no ping for a dry run, got %v and %d pings", resp.Result, len(pings))
+	}
+	if resp := validate(hook, newHook(func(h *GlobalTenantHook) {}), false); !resp.Allowed {
+		t.Fatal(resp.Result)
+	}
+	if len(pings) != 1 || pings[0].EventType != EventPing || pings[0].EventID == "" {
+		t.Errorf("expected a single ping, got %+v", pings)
+	}
+}
@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2024 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -27,143 +27,56 @@
 package v1alpha3
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-type HsmMemberId struct {
-	Id string
-}
-
-type HsmIds struct {
-	Ids []string
-}
+// The HSM exclusive group label assigned to groups with EnforceExclusiveHsmGroups set.
+const hsmExclusiveGroupLabel = "tapms-exclusive-group-label"
 
-type HsmPartition struct {
-	Name        string
-	Description string
-	Tags        []string
-	Members     HsmIds
-}
+// HsmClient is the HSM client used by the tenant reconciler and webhook.
+// It is set by Configure, and may be replaced (e.g. with a fake) before
+// the manager is started.
+var HsmClient hsm.Client
 
-type HsmGroup struct {
-	Label          string
-	Description    string
-	ExclusiveGroup string
-	Tags           []string
-	Members        HsmIds
-}
-type HsmComponent struct {
-	ID                  string
-	Type                string
-	State               string
-	Flag                string
-	Enabled             bool
-	SoftwareStatus      string
-	Role                string
-	SubRole             string
-	NID                 int32
-	Subtype             string
-	NetType             string
-	Arch                string
-	Class               string
-	ReservationDisabled bool
-	Locked              bool
-}
-type HsmComponentList struct {
-	Components []HsmComponent
+func hsmClient() hsm.Client {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return HsmClient
 }
 
-func ListHSMGroups(ctx context.Context, log logr.Logger) (ctrl.Result, []HsmGroup, error) {
-
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, nil, err
-	}
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/groups", GetApiGateway())
-	hsmGroup := HsmGroup{}
-	hsmGroupBytes, err := json.Marshal(hsmGroup)
+// gatewayToken returns a token for requests made through the API gateway.
+func gatewayToken(ctx context.Context) (string, error) {
+	_, token, err := GetToken(ctx, Log, false)
 	if err != nil {
-		return ctrl.Result{}, nil, err
+		return "", err
 	}
-	req, err := http.NewRequest(http.MethodGet, hsmUrl, bytes.NewBuffer(hsmGroupBytes))
-	if err != nil {
-		return ctrl.Result{}, nil, err
+	if token == "" {
+		return "", errors.New("failed to get token from keycloak for API gateway request")
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return ctrl.Result{}, nil, errors.New("HSM returned a non-200 response listing groups")
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
-	var hsmGroupList []HsmGroup
-	err = json.Unmarshal(body, &hsmGroupList)
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-
-	return ctrl.Result{}, hsmGroupList, nil
+	return token, nil
 }
 
-func ListHSMPartitions(ctx context.Context, log logr.Logger) (ctrl.Result, []HsmPartition, error) {
-
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, nil, err
-	}
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/partitions", GetApiGateway())
-	hsmPartition := HsmPartition{}
-	hsmPartitionBytes, err := json.Marshal(hsmPartition)
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-	req, err := http.NewRequest(http.MethodGet, hsmUrl, bytes.NewBuffer(hsmPartitionBytes))
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
+func ListHSMGroups(ctx context.Context, log logr.Logger) (ctrl.Result, []hsm.Group, error) {
+	groupList, err := hsmClient().ListGroups(ctx)
 	if err != nil {
 		return ctrl.Result{}, nil, err
 	}
+	return ctrl.Result{}, groupList, nil
+}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return ctrl.Result{}, nil, errors.New("HSM returned a non-200 response listing partitions")
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
-	var hsmPartitionList []HsmPartition
-	err = json.Unmarshal(body, &hsmPartitionList)
+func ListHSMPartitions(ctx context.Context, log logr.Logger) (ctrl.Result, []hsm.Partition, error) {
+	partitionList, err := hsmClient().ListPartitions(ctx)
 	if err != nil {
 		return ctrl.Result{}, nil, err
 	}
-
-	return ctrl.Result{}, hsmPartitionList, nil
+	return ctrl.Result{}, partitionList, nil
 }
 
 func DetermineHSMGroupChanges(ctx context.Context, log logr.Logger, tenant *Tenant) (ctrl.Result, error) {
@@ -171,7 +84,7 @@ func DetermineHSMGroupChanges(ctx context.Context, log logr.Logger, tenant *Tena
 	// First loop handles simple xname add/deletion from existing
 	// resource (compute/application) or initial HSM group creation.
 	//
-	for _, resource := range tenant.Spec.TenantResources {
+	for _, resource := range tenant.ResolvedResources() {
 		if len(resource.HsmGroupLabel) > 0 {
 			log.Info(fmt.Sprintf("Creating/updating HSM group for %s and resource type %s", tenant.Spec.TenantName, resource.Type))
 			result, err := updateHSMGroup(ctx, log, tenant, resource)
@@ -185,27 +98,32 @@ func DetermineHSMGroupChanges(ctx context.Context, log logr.Logger, tenant *Tena
 	//
 	// Second loop handles case where a resource group is removed.
 	//
-	for _, statResource := range tenant.Status.TenantResources {
-		haveSpec := false
-		if len(statResource.HsmGroupLabel) > 0 {
-			for _, resource := range tenant.Spec.TenantResources {
-				if statResource.Type == resource.Type {
-					haveSpec = true
-				}
-			}
-		}
-		if !haveSpec {
-			result, err := editHsmGroupMembers(ctx, log, tenant.Name, statResource.HsmGroupLabel, statResource.Xnames, http.MethodDelete, statResource.EnforceExclusiveHsmGroups)
-			if err != nil {
-				log.Error(err, "Failed to delete HSM group members")
-				return result, err
-			}
+	for _, removed := range removedHsmGroups(tenant) {
+		result, report, err := editHsmGroupMembers(ctx, log, removed.Name, removed.Xnames, http.MethodDelete)
+		tenant.applyMemberReport(HsmKindGroup, removed.Name, report, http.MethodDelete)
+		if err != nil {
+			log.Error(err, "Failed to delete HSM group members")
+			return result, err
 		}
+		tenant.removeAppliedHsmMembers(HsmKindGroup, removed.Name)
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// removedHsmGroups returns the applied HSM groups that are no longer
+// used by any resource in the spec.
+func removedHsmGroups(t *Tenant) []TenantHsmMembers {
+	var removed []TenantHsmMembers
+	desired := expectedHsmMembers(t, func(r TenantResource) string { return r.HsmGroupLabel })
+	for _, members := range t.appliedStatus().HsmGroups {
+		if _, ok := desired[members.Name]; !ok {
+			removed = append(removed, members)
+		}
+	}
+	return removed
+}
+
 func updateHSMGroup(ctx context.Context, log logr.Logger, t *Tenant, resource TenantResource) (ctrl.Result, error) {
 
 	result, groupList, err := ListHSMGroups(ctx, log)
@@ -229,53 +147,28 @@ func updateHSMGroup(ctx context.Context, log logr.Logger, t *Tenant, resource Te
 		if err != nil {
 			return result, err
 		}
+		t.RecordCreated(ConditionHsmGroupReady, JournalKindHsmGroup, resource.HsmGroupLabel)
+		t.setAppliedHsmMembers(HsmKindGroup, resource.HsmGroupLabel, append([]string(nil), resource.Xnames...))
 		return ctrl.Result{}, nil
 	} else {
 		//
-		// Check for any changes to update in the group
-		//
-		for _, statResource := range t.Status.TenantResources {
-			if statResource.Type == resource.Type {
-				log.Info(fmt.Sprintf("Checking for members deleted from HSM group %s and type %s", resource.HsmGroupLabel, resource.Type))
-				deletedMembers := Difference(statResource.Xnames, resource.Xnames)
-				result, err := editHsmGroupMembers(ctx, log, t.Name, resource.HsmGroupLabel, deletedMembers, http.MethodDelete, resource.EnforceExclusiveHsmGroups)
-				if err != nil {
-					log.Error(err, "Failed to delete HSM group members")
-					return result, err
-				}
-				log.Info(fmt.Sprintf("Checking for members added from HSM group %s and type %s", resource.HsmGroupLabel, resource.Type))
-				addedMembers := Difference(resource.Xnames, statResource.Xnames)
-				result, err = editHsmGroupMembers(ctx, log, t.Name, resource.HsmGroupLabel, addedMembers, http.MethodPost, resource.EnforceExclusiveHsmGroups)
-				if err != nil {
-					log.Error(err, "Failed to add HSM group members")
-					return result, err
-				}
-			}
-		}
-
-		//
-		// Handles case where a resource group is added after the
+		// Check for any changes to update in the group. This also
+		// handles the case where a resource group is added after the
 		// HSM group is created.
 		//
-		for _, specResource := range t.Spec.TenantResources {
-			if specResource.Type != resource.Type {
-				continue
-			}
-			if len(specResource.HsmGroupLabel) > 0 {
-				haveStatus := false
-				for _, statResource := range t.Status.TenantResources {
-					if specResource.Type == statResource.Type {
-						haveStatus = true
-					}
-				}
-				if !haveStatus {
-					result, err := editHsmGroupMembers(ctx, log, t.Name, specResource.HsmGroupLabel, specResource.Xnames, http.MethodPost, specResource.EnforceExclusiveHsmGroups)
-					if err != nil {
-						log.Error(err, "Failed to add HSM group members")
-						return result, err
-					}
-				}
-			}
+		log.Info(fmt.Sprintf("Checking for members changed in HSM group %s and type %s", resource.HsmGroupLabel, resource.Type))
+		addedMembers, deletedMembers := hsmMemberChanges(t, HsmKindGroup, resource.HsmGroupLabel)
+		result, report, err := editHsmGroupMembers(ctx, log, resource.HsmGroupLabel, deletedMembers, http.MethodDelete)
+		t.applyMemberReport(HsmKindGroup, resource.HsmGroupLabel, report, http.MethodDelete)
+		if err != nil {
+			log.Error(err, "Failed to delete HSM group members")
+			return result, err
+		}
+		result, report, err = editHsmGroupMembers(ctx, log, resource.HsmGroupLabel, addedMembers, http.MethodPost)
+		t.applyMemberReport(HsmKindGroup, resource.HsmGroupLabel, report, http.MethodPost)
+		if err != nil {
+			log.Error(err, "Failed to add HSM group members")
+			return result, err
 		}
 	}
 
@@ -305,413 +198,160 @@ func UpdateHSMPartition(ctx context.Context, log logr.Logger, t *Tenant, hsmPart
 		if err != nil {
 			return result, err
 		}
+		t.RecordCreated(ConditionHsmPartitionReady, JournalKindHsmPartition, hsmPartitionName)
+		t.setAppliedHsmMembers(HsmKindPartition, hsmPartitionName, append([]string(nil), xnames...))
 		return ctrl.Result{}, nil
 	} else {
 		//
 		// Check for any changes to update in the partition
 		//
-		for _, specResource := range t.Spec.TenantResources {
-			for _, statResource := range t.Status.TenantResources {
-				if (statResource.Type == specResource.Type) && (specResource.HsmPartitionName == hsmPartitionName) {
-					log.Info(fmt.Sprintf("Checking for members deleted from HSM partition %s", specResource.HsmPartitionName))
-					deletedMembers := Difference(statResource.Xnames, specResource.Xnames)
-					result, err := editHsmPartitionMembers(ctx, log, t.Name, hsmPartitionName, deletedMembers, http.MethodDelete)
-					if err != nil {
-						log.Error(err, "Failed to delete HSM partition members")
-						return result, err
-					}
-				}
-			}
+		log.Info(fmt.Sprintf("Checking for members changed in HSM partition %s", hsmPartitionName))
+		addedMembers, deletedMembers := hsmMemberChanges(t, HsmKindPartition, hsmPartitionName)
+		result, report, err := editHsmPartitionMembers(ctx, log, hsmPartitionName, deletedMembers, http.MethodDelete)
+		t.applyMemberReport(HsmKindPartition, hsmPartitionName, report, http.MethodDelete)
+		if err != nil {
+			log.Error(err, "Failed to delete HSM partition members")
+			return result, err
 		}
-		for _, specResource := range t.Spec.TenantResources {
-			for _, statResource := range t.Status.TenantResources {
-				if (statResource.Type == specResource.Type) && (specResource.HsmPartitionName == hsmPartitionName) {
-					log.Info(fmt.Sprintf("Checking for members added to HSM partition %s", specResource.HsmPartitionName))
-					addedMembers := Difference(specResource.Xnames, statResource.Xnames)
-					result, err = editHsmPartitionMembers(ctx, log, t.Name, hsmPartitionName, addedMembers, http.MethodPost)
-					if err != nil {
-						log.Error(err, "Failed to add HSM partition members")
-						return result, err
-					}
-				}
-			}
+		result, report, err = editHsmPartitionMembers(ctx, log, hsmPartitionName, addedMembers, http.MethodPost)
+		t.applyMemberReport(HsmKindPartition, hsmPartitionName, report, http.MethodPost)
+		if err != nil {
+			log.Error(err, "Failed to add HSM partition members")
+			return result, err
 		}
 	}
 	return ctrl.Result{}, nil
 }
 
-func editHsmGroupMembers(ctx context.Context, log logr.Logger, tenantName string, hsmGroupLabel string, changedMembers []string, httpMethod string, enforceExclusiveHsmGroups bool) (ctrl.Result, error) {
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	for _, member := range changedMembers {
-
-		hsmUrl := ""
-		action := ""
-		hsmGroupBytes := []byte{}
+func editHsmGroupMembers(ctx context.Context, log logr.Logger, hsmGroupLabel string, changedMembers []string, httpMethod string) (ctrl.Result, hsm.MemberReport, error) {
+	client := hsmClient()
+	edit := func(ctx context.Context, member string) error {
 		if httpMethod == http.MethodPost {
-			hsmUrl = fmt.Sprintf("https://%s/apis/smd/hsm/v2/groups/%s/members", GetApiGateway(), hsmGroupLabel)
-			action = "adding"
-			hsmId := HsmMemberId{}
-			hsmId.Id = member
-			hsmGroupBytes, err = json.Marshal(hsmId)
-			if err != nil {
-				return ctrl.Result{}, err
-			}
-		} else if httpMethod == http.MethodDelete {
-			hsmUrl = fmt.Sprintf("https://%s/apis/smd/hsm/v2/groups/%s/members/%s", GetApiGateway(), hsmGroupLabel, member)
-			action = "removing"
-			memberArray := []string{member}
-			result, hsmGroupBytes, err = buildHsmGroupPayload(log, tenantName, hsmGroupLabel, memberArray, enforceExclusiveHsmGroups)
-			if err != nil {
-				return result, err
-			}
-		}
-
-		req, err := http.NewRequest(httpMethod, hsmUrl, bytes.NewBuffer(hsmGroupBytes))
-		if err != nil {
-			return ctrl.Result{}, err
+			return client.AddGroupMember(ctx, hsmGroupLabel, member)
 		}
-
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-		HTTPClient := NewHttpClient()
-		resp, err := HTTPClient.Do(req)
+		return client.RemoveGroupMember(ctx, hsmGroupLabel, member)
+	}
+	members := func(ctx context.Context) ([]string, error) {
+		group, err := client.GetGroup(ctx, hsmGroupLabel)
 		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		defer resp.Body.Close()
-
-		if resp.StatusCode < 200 || resp.StatusCode > 299 {
-			if resp.StatusCode == 404 {
-				log.Info(fmt.Sprintf("HSM member %s already deleted from group %s", member, hsmGroupLabel))
-			} else if resp.StatusCode == 409 {
-				log.Info(fmt.Sprintf("HSM member %s already added to partition %s", member, hsmGroupLabel))
-			} else {
-				return ctrl.Result{}, fmt.Errorf("HSM returned a non-200 response %s member %s for group %s", action, member, hsmGroupLabel)
-			}
+			return nil, err
 		}
+		return group.Members.Ids, nil
 	}
-
-	return ctrl.Result{}, nil
+	report := hsm.EditMembers(ctx, memberOp(httpMethod), changedMembers, currentConfig().Hsm.MaxParallelRequests, edit, members)
+	logMemberReport(log, report, httpMethod, "group "+hsmGroupLabel)
+	return ctrl.Result{}, report, report.Err()
 }
 
-func editHsmPartitionMembers(ctx context.Context, log logr.Logger, tenantName string, hsmPartitionName string, changedMembers []string, httpMethod string) (ctrl.Result, error) {
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	for _, member := range changedMembers {
-
-		hsmUrl := ""
-		action := ""
-		hsmPartitionBytes := []byte{}
+func editHsmPartitionMembers(ctx context.Context, log logr.Logger, hsmPartitionName string, changedMembers []string, httpMethod string) (ctrl.Result, hsm.MemberReport, error) {
+	client := hsmClient()
+	edit := func(ctx context.Context, member string) error {
 		if httpMethod == http.MethodPost {
-			hsmUrl = fmt.Sprintf("https://%s/apis/smd/hsm/v2/partitions/%s/members", GetApiGateway(), hsmPartitionName)
-			action = "adding"
-			hsmId := HsmMemberId{}
-			hsmId.Id = member
-			hsmPartitionBytes, err = json.Marshal(hsmId)
-			if err != nil {
-				return ctrl.Result{}, err
-			}
-		} else if httpMethod == http.MethodDelete {
-			hsmUrl = fmt.Sprintf("https://%s/apis/smd/hsm/v2/partitions/%s/members/%s", GetApiGateway(), hsmPartitionName, member)
-			action = "removing"
-			memberArray := []string{member}
-			result, hsmPartitionBytes, err = buildHsmPartitionPayload(log, tenantName, hsmPartitionName, memberArray)
-			if err != nil {
-				return result, err
-			}
-		}
-
-		req, err := http.NewRequest(httpMethod, hsmUrl, bytes.NewBuffer(hsmPartitionBytes))
-		if err != nil {
-			return ctrl.Result{}, err
+			return client.AddPartitionMember(ctx, hsmPartitionName, member)
 		}
-
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-		HTTPClient := NewHttpClient()
-		resp, err := HTTPClient.Do(req)
+		return client.RemovePartitionMember(ctx, hsmPartitionName, member)
+	}
+	members := func(ctx context.Context) ([]string, error) {
+		partition, err := client.GetPartition(ctx, hsmPartitionName)
 		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		defer resp.Body.Close()
-
-		if resp.StatusCode < 200 || resp.StatusCode > 299 {
-			if resp.StatusCode == 404 {
-				log.Info(fmt.Sprintf("HSM member %s already deleted from partition %s", member, hsmPartitionName))
-			} else if resp.StatusCode == 409 {
-				log.Info(fmt.Sprintf("HSM member %s already added to partition %s", member, hsmPartitionName))
-			} else {
-				return ctrl.Result{}, fmt.Errorf("HSM returned a non-200 response %s member %s for partition %s", action, member, hsmPartitionName)
-			}
+			return nil, err
 		}
+		return partition.Members.Ids, nil
 	}
+	report := hsm.EditMembers(ctx, memberOp(httpMethod), changedMembers, currentConfig().Hsm.MaxParallelRequests, edit, members)
+	logMemberReport(log, report, httpMethod, "partition "+hsmPartitionName)
+	return ctrl.Result{}, report, report.Err()
+}
 
-	return ctrl.Result{}, nil
+// memberOp returns the hsm.EditMembers operation for an HTTP method.
+func memberOp(httpMethod string) string {
+	if httpMethod == http.MethodPost {
+		return hsm.MemberAdd
+	}
+	return hsm.MemberRemove
 }
 
-func buildHsmPartitionPayload(log logr.Logger, tenantName string, hsmPartitionName string, xnames []string) (ctrl.Result, []byte, error) {
+// logMemberReport logs how many members of an HSM group or partition were
+// added or removed. Failed members are in the returned error.
+func logMemberReport(log logr.Logger, report hsm.MemberReport, httpMethod string, what string) {
+	if len(report) == 0 {
+		return
+	}
+	action := "Removed"
+	unchanged := "already removed"
+	if httpMethod == http.MethodPost {
+		action = "Added"
+		unchanged = "already added"
+	}
+	log.Info(fmt.Sprintf("%s %d of %d members for HSM %s (%d %s, %d failed)", action, report.Count(hsm.MemberChanged), len(report),
+		what, report.Count(hsm.MemberUnchanged), unchanged, report.Count(hsm.MemberFailed)))
+}
 
-	hsmPartition := HsmPartition{}
+func buildHsmPartition(tenantName string, hsmPartitionName string, xnames []string) hsm.Partition {
+	hsmPartition := hsm.Partition{}
 	hsmPartition.Name = hsmPartitionName
 	hsmPartition.Tags = append(hsmPartition.Tags, tenantName)
 	hsmPartition.Members.Ids = append(hsmPartition.Members.Ids, xnames...)
-	hsmPartitionBytes, err := json.Marshal(hsmPartition)
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-	return ctrl.Result{}, hsmPartitionBytes, err
+	return hsmPartition
 }
 
-func buildHsmGroupPayload(log logr.Logger, tenantName string, hsmGroupLabel string, xnames []string, enforceExclusiveHsmGroups bool) (ctrl.Result, []byte, error) {
-
-	hsmGroup := HsmGroup{}
+func buildHsmGroup(tenantName string, hsmGroupLabel string, xnames []string, enforceExclusiveHsmGroups bool) hsm.Group {
+	hsmGroup := hsm.Group{}
 	hsmGroup.Label = hsmGroupLabel
 	if enforceExclusiveHsmGroups {
-		hsmGroup.ExclusiveGroup = "tapms-exclusive-group-label"
+		hsmGroup.ExclusiveGroup = hsmExclusiveGroupLabel
 	} else {
 		hsmGroup.ExclusiveGroup = ""
 	}
 	hsmGroup.Tags = append(hsmGroup.Tags, tenantName)
-	for _, xname := range xnames {
-		hsmGroup.Members.Ids = append(hsmGroup.Members.Ids, xname)
-	}
-	hsmGroupBytes, err := json.Marshal(hsmGroup)
-	if err != nil {
-		return ctrl.Result{}, nil, err
-	}
-	return ctrl.Result{}, hsmGroupBytes, err
+	hsmGroup.Members.Ids = append(hsmGroup.Members.Ids, xnames...)
+	return hsmGroup
 }
 
 func createHSMGroup(ctx context.Context, log logr.Logger, tenantName string, hsmGroupLabel string, xnames []string, enforceExclusiveHsmGroups bool) (ctrl.Result, error) {
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/groups", GetApiGateway())
-	result, hsmGroupBytes, err := buildHsmGroupPayload(log, tenantName, hsmGroupLabel, xnames, enforceExclusiveHsmGroups)
-	if err != nil {
-		return result, err
-	}
-
-	req, err := http.NewRequest(http.MethodPost, hsmUrl, bytes.NewBuffer(hsmGroupBytes))
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
+	err := hsmClient().CreateGroup(ctx, buildHsmGroup(tenantName, hsmGroupLabel, xnames, enforceExclusiveHsmGroups))
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Created HSM group: " + hsmGroupLabel)
-		return ctrl.Result{}, nil
-	}
-	return ctrl.Result{}, errors.New("HSM returned a non-200 response creating group")
+	log.Info("Created HSM group: " + hsmGroupLabel)
+	return ctrl.Result{}, nil
 }
 
 func createHSMPartition(ctx context.Context, log logr.Logger, tenantName string, hsmPartitionName string, xnames []string) (ctrl.Result, error) {
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/partitions", GetApiGateway())
-	result, hsmPartitionBytes, err := buildHsmPartitionPayload(log, tenantName, hsmPartitionName, xnames)
-	if err != nil {
-		return result, err
-	}
-
-	req, err := http.NewRequest(http.MethodPost, hsmUrl, bytes.NewBuffer(hsmPartitionBytes))
+	err := hsmClient().CreatePartition(ctx, buildHsmPartition(tenantName, hsmPartitionName, xnames))
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Created HSM partition: " + hsmPartitionName)
-		return ctrl.Result{}, nil
-	}
-	return ctrl.Result{}, errors.New("HSM returned a non-200 response creating partition")
+	log.Info("Created HSM partition: " + hsmPartitionName)
+	return ctrl.Result{}, nil
 }
 
 func DeleteHSMGroup(ctx context.Context, log logr.Logger, hsmGroupLabel string) (ctrl.Result, error) {
-
-	result, groupList, err := ListHSMGroups(ctx, log)
+	err := hsmClient().DeleteGroup(ctx, hsmGroupLabel)
 	if err != nil {
-		return result, err
-	}
-
-	foundGroup := false
-	for _, group := range groupList {
-		if group.Label == hsmGroupLabel {
-			foundGroup = true
-			break
+		if hsm.IsNotFound(err) {
+			log.Info("HSM group already deleted: " + hsmGroupLabel)
+			return ctrl.Result{}, nil
 		}
-	}
-
-	if !foundGroup {
-		log.Info("HSM group already deleted: " + hsmGroupLabel)
-		return ctrl.Result{}, nil
-	}
-
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/groups/%s", GetApiGateway(), hsmGroupLabel)
-	hsmGroup := HsmGroup{}
-	hsmGroup.Label = hsmGroupLabel
-	hsmGroupBytes, err := json.Marshal(hsmGroup)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	req, err := http.NewRequest(http.MethodDelete, hsmUrl, bytes.NewBuffer(hsmGroupBytes))
-	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Deleted HSM group: " + hsmGroup.Label)
-		return ctrl.Result{}, nil
-	}
-	return ctrl.Result{}, errors.New("HSM returned a non-200 response deleting group")
+	log.Info("Deleted HSM group: " + hsmGroupLabel)
+	return ctrl.Result{}, nil
 }
 
 func DeleteHSMPartition(ctx context.Context, log logr.Logger, hsmPartitionName string) (ctrl.Result, error) {
-
-	result, partitionList, err := ListHSMPartitions(ctx, log)
+	err := hsmClient().DeletePartition(ctx, hsmPartitionName)
 	if err != nil {
-		return result, err
-	}
-
-	foundPartition := false
-	for _, partition := range partitionList {
-		if partition.Name == hsmPartitionName {
-			foundPartition = true
-			break
+		if hsm.IsNotFound(err) {
+			log.Info("HSM partition already deleted: " + hsmPartitionName)
+			return ctrl.Result{}, nil
 		}
-	}
-
-	if !foundPartition {
-		log.Info("HSM partition already deleted: " + hsmPartitionName)
-		return ctrl.Result{}, nil
-	}
-
-	result, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return result, err
-	}
-
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/partitions/%s", GetApiGateway(), hsmPartitionName)
-	hsmPartition := HsmPartition{}
-	hsmPartition.Name = hsmPartitionName
-	hsmPartitionBytes, err := json.Marshal(hsmPartition)
-	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	req, err := http.NewRequest(http.MethodDelete, hsmUrl, bytes.NewBuffer(hsmPartitionBytes))
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Deleted HSM partition: " + hsmPartition.Name)
-		return ctrl.Result{}, nil
-	}
-	return ctrl.Result{}, errors.New("HSM returned a non-200 response deleting partition")
+	log.Info("Deleted HSM partition: " + hsmPartitionName)
+	return ctrl.Result{}, nil
 }
 
-func GetComponentList(ctx context.Context, log logr.Logger, nodeType string, role string) (*HsmComponentList, error) {
-
-	_, token, err := GetToken(ctx, log, false)
-	if err != nil {
-		return nil, err
-	}
-	hsmUrl := fmt.Sprintf("https://%s/apis/smd/hsm/v2/State/Components?type=%s&role=%s", GetApiGateway(), nodeType, role)
-	hsmComponentList := HsmComponentList{}
-	hsmComponentListBytes, err := json.Marshal(hsmComponentList)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest(http.MethodGet, hsmUrl, bytes.NewBuffer(hsmComponentListBytes))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-
-	HTTPClient := NewHttpClient()
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, errors.New("HSM returned a non-200 response listing components")
-	}
-
-	body, _ := ioutil.ReadAll(resp.Body)
-	err = json.Unmarshal(body, &hsmComponentList)
-	if err != nil {
-		return nil, err
-	}
-
-	return &hsmComponentList, nil
+func GetComponentList(ctx context.Context, log logr.Logger, nodeType string, role string) (*hsm.ComponentList, error) {
+	return hsmClient().ListComponents(ctx, nodeType, role)
 }
@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -54,10 +54,17 @@ func DeleteChildNamespaces(ctx context.Context, log logr.Logger, client client.C
 				return ctrl.Result{}, err
 			}
 		}
+		t.SetChildNamespaceApplied(childNs, false)
 	}
 	return ctrl.Result{}, nil
 }
 
+// DeletedChildNamespaces returns the child namespaces that were applied
+// but are no longer in the tenant spec.
+func DeletedChildNamespaces(t *Tenant) []string {
+	return Difference(t.appliedChildNamespaces(), t.Spec.ChildNamespaces)
+}
+
 func SubNSAnchorForTenant(parentNs string, childNs string) *api.SubnamespaceAnchor {
 
 	anchor := &api.SubnamespaceAnchor{
@@ -84,25 +91,27 @@ func HierarchyConfigForTenant(parentNs string, childNs string) *api.HierarchyCon
 	return hierarchy
 }
 
-func CreateSubanchorNs(ctx context.Context, log logr.Logger, client client.Client, parentNs string, childNs string) (ctrl.Result, error) {
+// CreateSubanchorNs creates the subnamespace anchor for childNs, returning
+// true if it did not already exist.
+func CreateSubanchorNs(ctx context.Context, log logr.Logger, client client.Client, parentNs string, childNs string) (ctrl.Result, bool, error) {
 	subNsAnchor := SubNSAnchorForTenant(parentNs, childNs)
 	err := client.Create(ctx, subNsAnchor)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
 			log.Info("Subanchor: " + childNs + " in parent namespace: " + parentNs + " already exists")
-			return ctrl.Result{}, nil
+			return ctrl.Result{}, false, nil
 		} else if k8serrors.IsNotFound(err) {
 			//
 			// It can take the hnc-manager a bit to create namespaces,
 			// so if we get namespace not found, we'll try again.
 			//
-			return ctrl.Result{Requeue: true}, nil
+			return ctrl.Result{Requeue: true}, false, nil
 		}
-		return ctrl.Result{}, err
+		return ctrl.Result{}, false, err
 	}
 
 	log.Info("Created subanchor: " + childNs + " in parent namespace: " + parentNs)
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, true, nil
 }
 
 func CreateHierarchyConfigForNs(ctx context.Context, log logr.Logger, client client.Client, parentNs string, childNs string) (ctrl.Result, error) {
@@ -0,0 +1,135 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Headers added to hook calls whose secret has an hmac-key. The signature
+// is "sha256=" followed by the hex HMAC-SHA256 of the timestamp, a ".",
+// and the request body, so receivers can reject forged or replayed calls.
+const (
+	HookTimestampHeader = "X-Tapms-Timestamp"
+	HookSignatureHeader = "X-Tapms-Signature"
+)
+
+// Keys recognized in a hook's secret.
+const (
+	hookSecretHmacKey = "hmac-key"
+	hookSecretToken   = "token"
+	hookSecretCert    = corev1.TLSCertKey
+	hookSecretKey     = corev1.TLSPrivateKeyKey
+	hookSecretCA      = "ca.crt"
+)
+
+// HookSecretsReader reads the secrets referenced by hooks. It defaults to
+// HooksClient, but should be an uncached reader so the manager does not
+// cache every secret in the cluster.
+var HookSecretsReader client.Reader
+
+// hookCredentials are the credentials for calling a hook, from its secret.
+type hookCredentials struct {
+	hmacKey   []byte
+	token     string
+	tlsConfig *tls.Config
+}
+
+// getHookCredentials reads the credentials for a hook from the named
+// secret. Server certificates are verified when a hook has a secret, using
+// ca.crt if present or the system roots otherwise.
+func getHookCredentials(ctx context.Context, namespace string, secretName string) (*hookCredentials, error) {
+	reader := HookSecretsReader
+	if reader == nil {
+		reader = HooksClient
+	}
+	secret := &corev1.Secret{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hook secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	creds := &hookCredentials{
+		hmacKey:   secret.Data[hookSecretHmacKey],
+		token:     string(secret.Data[hookSecretToken]),
+		tlsConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	if ca, ok := secret.Data[hookSecretCA]; ok {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("hook secret %s/%s has no valid certificates in %s", namespace, secretName, hookSecretCA)
+		}
+		creds.tlsConfig.RootCAs = pool
+	}
+	cert, hasCert := secret.Data[hookSecretCert]
+	key, hasKey := secret.Data[hookSecretKey]
+	if hasCert != hasKey {
+		return nil, fmt.Errorf("hook secret %s/%s must have both %s and %s for a client certificate", namespace, secretName, hookSecretCert, hookSecretKey)
+	}
+	if hasCert {
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in hook secret %s/%s: %w", namespace, secretName, err)
+		}
+		creds.tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	return creds, nil
+}
+
+// authorize adds the signature and bearer token headers to a hook call.
+func (c *hookCredentials) authorize(req *http.Request, body []byte, now time.Time) {
+	if len(c.hmacKey) > 0 {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(HookTimestampHeader, timestamp)
+		req.Header.Set(HookSignatureHeader, hookSignature(c.hmacKey, timestamp, body))
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+// hookSignature returns the HookSignatureHeader value for a hook call.
+func hookSignature(key []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
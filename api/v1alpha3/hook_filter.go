@@ -0,0 +1,130 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// The tenant spec fields a global hook's ChangedFields can filter on, by
+// their JSON names.
+var changedFieldValues = map[string]func(TenantSpec) interface{}{
+	"childnamespaces": func(s TenantSpec) interface{} { return s.ChildNamespaces },
+	"tenantresources": func(s TenantSpec) interface{} { return s.TenantResources },
+	"tenantkms":       func(s TenantSpec) interface{} { return s.TenantKmsResource },
+	"tenanthooks":     func(s TenantSpec) interface{} { return s.TenantHooks },
+	"driftpolicy":     func(s TenantSpec) interface{} { return s.DriftPolicy },
+	"failurepolicy":   func(s TenantSpec) interface{} { return s.FailurePolicy },
+}
+
+// changedFieldNames returns the fields a global hook can filter on.
+func changedFieldNames() []string {
+	var names []string
+	for name := range changedFieldValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Matches returns true if the global hook is called for an event for
+// tenant. The old tenant is only set for UPDATE events.
+func (s *GlobalTenantHookSpec) Matches(tenant *Tenant, old *Tenant) (bool, error) {
+	if s.TenantSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(s.TenantSelector)
+		if err != nil {
+			return false, fmt.Errorf("global hook '%s' has an invalid tenant selector: %w", s.Name, err)
+		}
+		if !selector.Matches(labels.Set(tenant.Labels)) {
+			return false, nil
+		}
+	}
+
+	if len(s.ResourceTypes) > 0 && !containsAny(s.ResourceTypes, changedResourceTypes(tenant, old)) {
+		return false, nil
+	}
+
+	if len(s.ChangedFields) > 0 && old != nil {
+		for _, field := range s.ChangedFields {
+			if _, ok := changedFieldValues[field]; !ok {
+				return false, fmt.Errorf("global hook '%s' filters on unknown field '%s'", s.Name, field)
+			}
+		}
+		if !containsAny(s.ChangedFields, changedFields(old.Spec, tenant.Spec)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// changedResourceTypes returns the resource types whose resolved xnames or
+// settings differ between the old and new tenant, or every resource type of the
+// tenant if there is no old tenant.
+func changedResourceTypes(tenant *Tenant, old *Tenant) []string {
+	var resourceTypes []string
+	resources := tenant.ResolvedResources()
+	if old == nil {
+		for _, resource := range resources {
+			resourceTypes = appendUnique(resourceTypes, []string{resource.Type})
+		}
+		return resourceTypes
+	}
+	oldResources := old.ResolvedResources()
+	for _, resource := range append(append([]TenantResource{}, oldResources...), resources...) {
+		if !reflect.DeepEqual(findResource(oldResources, resource.Type), findResource(resources, resource.Type)) {
+			resourceTypes = appendUnique(resourceTypes, []string{resource.Type})
+		}
+	}
+	return resourceTypes
+}
+
+// changedFields returns the JSON names of the tenant spec fields that
+// differ between old and new.
+func changedFields(old TenantSpec, new TenantSpec) []string {
+	var fields []string
+	for field, value := range changedFieldValues {
+		if !reflect.DeepEqual(value(old), value(new)) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func containsAny(slice []string, values []string) bool {
+	for _, value := range values {
+		if Contains(slice, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGlobalTenantHookMatches(t *testing.T) {
+	old := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Labels: map[string]string{"site": "west"}},
+		Spec: TenantSpec{
+			TenantName:      "vcluster-blue",
+			ChildNamespaces: []string{"slurm"},
+			TenantResources: []TenantResource{
+				{Type: "compute", Xnames: []string{"x0c3s5b0n0"}},
+				{Type: "application", Xnames: []string{"x3000c0s19b1n0"}},
+			},
+		},
+	}
+	tenant := old.DeepCopy()
+	tenant.Spec.TenantResources[0].Xnames = []string{"x0c3s6b0n0"}
+
+	tests := []struct {
+		name    string
+		spec    GlobalTenantHookSpec
+		old     *Tenant
+		matches bool
+	}{
+		{"no filters", GlobalTenantHookSpec{}, old, true},
+		{"matching selector", GlobalTenantHookSpec{TenantSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"site": "west"}}}, old, true},
+		{"other selector", GlobalTenantHookSpec{TenantSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"site": "east"}}}, old, false},
+		{"changed resource type", GlobalTenantHookSpec{ResourceTypes: []string{"compute"}}, old, true},
+		{"unchanged resource type", GlobalTenantHookSpec{ResourceTypes: []string{"application"}}, old, false},
+		{"resource type without old tenant", GlobalTenantHookSpec{ResourceTypes: []string{"application"}}, nil, true},
+		{"changed field", GlobalTenantHookSpec{ChangedFields: []string{"tenantresources"}}, old, true},
+		{"unchanged field", GlobalTenantHookSpec{ChangedFields: []string{"childnamespaces"}}, old, false},
+		{"field without old tenant", GlobalTenantHookSpec{ChangedFields: []string{"childnamespaces"}}, nil, true},
+	}
+	for _, test := range tests {
+		matches, err := test.spec.Matches(tenant, test.old)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if matches != test.matches {
+			t.Errorf("%s: expected matches %v, got %v", test.name, test.matches, matches)
+		}
+	}
+
+	patterned := old.DeepCopy()
+	patterned.Spec.TenantResources[1].XnamePatterns = []string{"x3000c0s[19-20]b1n0"}
+	patterned.Status.ResolvedXnames = []TenantResolvedXnames{{Type: "application", Xnames: []string{"x3000c0s19b1n0"}}}
+	resolved := patterned.DeepCopy()
+	resolved.Status.ResolvedXnames[0].Xnames = []string{"x3000c0s19b1n0", "x3000c0s20b1n0"}
+	spec := GlobalTenantHookSpec{ResourceTypes: []string{"application"}}
+	matches, err := spec.Matches(resolved, patterned)
+	if err != nil || !matches {
+		t.Errorf("expected a match on newly resolved xnames, got %v, %v", matches, err)
+	}
+
+	spec = GlobalTenantHookSpec{ChangedFields: []string{"tenantname"}}
+	if _, err := spec.Matches(tenant, old); err == nil {
+		t.Error("expected an error for an unknown changed field")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
+)
+
+// HookDelivery phases recorded in Status.Phase.
+const (
+	HookDeliveryPending   = "Pending"
+	HookDeliveryDelivered = "Delivered"
+	HookDeliveryFailed    = "Failed"
+)
+
+// HookDeliveryTenantLabel labels a HookDelivery with the name of the
+// Tenant object it is for, in the same namespace.
+const HookDeliveryTenantLabel = "tapms.hpe.com/tenant"
+
+// HookDeliveryBackoff is the delay between delivery attempts. A delivery
+// is marked failed after Steps attempts, roughly three hours.
+var HookDeliveryBackoff = retry.Backoff{
+	Initial:    10 * time.Second,
+	Max:        30 * time.Minute,
+	Multiplier: 2,
+	Jitter:     0.2,
+	Steps:      12,
+}
+
+// HookTimeout limits each call to a hook, so a hook that never responds
+// fails the attempt rather than holding up other deliveries. It is shorter
+// than the admission webhook timeout, as blocking hooks are called during
+// admission.
+var HookTimeout = 10 * time.Second
+
+// HookDeliveryRetention is how long delivered and failed deliveries are
+// kept as a record before they are deleted.
+const HookDeliveryRetention = 7 * 24 * time.Hour
+
+// queueHookDelivery records an event for delivery to a notify hook.
+func queueHookDelivery(ctx context.Context, hook TenantHook, namespace string, tenant *Tenant, event string, payload []byte) error {
+	delivery := &HookDelivery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", tenant.Name, strings.ToLower(event)),
+			Namespace:    tenant.Namespace,
+			Labels:       map[string]string{HookDeliveryTenantLabel: tenant.Name},
+		},
+		Spec: HookDeliverySpec{
+			Hook:            hook,
+			SecretNamespace: namespace,
+			TenantName:      tenant.Spec.TenantName,
+			EventType:       event,
+			Payload:         string(payload),
+		},
+	}
+	err := HooksClient.Create(ctx, delivery)
+	if err != nil {
+		return err
+	}
+	Log.Info(fmt.Sprintf("Queued %s delivery %s to '%s' hook at url %s", event, delivery.Name, hook.Name, hook.Url))
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestQueueLifecycleHooks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	HooksClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&GlobalTenantHook{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "tenants"},
+		Spec:       GlobalTenantHookSpec{TenantHook: TenantHook{Name: "global", Url: "http://global", EventTypes: []string{"CREATE"}}},
+	}).Build()
+	defer func() { HooksClient = nil }()
+
+	tenant := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"},
+		Spec: TenantSpec{
+			TenantName:  "vcluster-blue",
+			TenantHooks: []TenantHook{{Name: "wlm", BlockingCall: true, Url: "http://wlm", EventTypes: []string{EventProvisioned}}},
+		},
+		Status: TenantStatus{Phase: PhaseDeployed, UUID: "550e8400-e29b-41d4-a716-446655440000"},
+	}
+	ctx := context.Background()
+	if err := QueueLifecycleHooks(ctx, tenant, ctrl.Log, EventProvisioned); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries := &HookDeliveryList{}
+	if err := HooksClient.List(ctx, deliveries); err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries.Items) != 1 || deliveries.Items[0].Spec.Hook.Name != "wlm" {
+		t.Fatalf("expected a single delivery to the wlm hook, got %+v", deliveries.Items)
+	}
+	delivery := deliveries.Items[0]
+	if delivery.Labels[HookDeliveryTenantLabel] != "vcluster-blue" || delivery.Spec.SecretNamespace != "tenants" {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+	var payload TenantEventPayload
+	if err := json.Unmarshal([]byte(delivery.Spec.Payload), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.EventType != EventProvisioned || payload.TenantStatus == nil || payload.TenantStatus.UUID != tenant.Status.UUID {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
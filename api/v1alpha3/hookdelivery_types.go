@@ -0,0 +1,88 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// @Description The event and hook for a single delivery to a notify hook
+type HookDeliverySpec struct {
+	// The hook to deliver the event to, as it was when the event occurred.
+	Hook TenantHook `json:"hook"`
+	// The namespace the hook's secret is read from.
+	SecretNamespace string `json:"secretnamespace,omitempty" example:"tenants"`
+	// The name of the tenant the event is for.
+	TenantName string `json:"tenantname" example:"vcluster-blue"`
+	EventType  string `json:"eventtype" example:"CREATE"`
+	// The JSON payload delivered to the hook.
+	Payload string `json:"payload"`
+} // @name HookDeliverySpec
+
+// @Description The outcome of the delivery attempts to a notify hook
+type HookDeliveryStatus struct {
+	Phase string `json:"phase,omitempty" example:"Pending,Delivered,Failed"`
+	// The number of failed delivery attempts.
+	Failures int32 `json:"failures,omitempty"`
+	// The error returned by the most recent failed attempt.
+	LastError   string       `json:"lasterror,omitempty"`
+	LastAttempt *metav1.Time `json:"lastattempt,omitempty" swaggertype:"string" format:"date-time"`
+	LastSuccess *metav1.Time `json:"lastsuccess,omitempty" swaggertype:"string" format:"date-time"`
+	// When the next attempt will be made, while the delivery is pending.
+	NextAttempt *metav1.Time `json:"nextattempt,omitempty" swaggertype:"string" format:"date-time"`
+} // @name HookDeliveryStatus
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenantname`
+//+kubebuilder:printcolumn:name="Hook",type=string,JSONPath=`.spec.hook.name`
+//+kubebuilder:printcolumn:name="Event",type=string,JSONPath=`.spec.eventtype`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Failures",type=integer,JSONPath=`.status.failures`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// @Description A tenant event queued for delivery to a notify (non-blocking) hook
+type HookDelivery struct {
+	metav1.TypeMeta   `json:",inline" swaggerignore:"true"`
+	metav1.ObjectMeta `json:"metadata,omitempty" swaggerignore:"true"`
+	Spec              HookDeliverySpec   `json:"spec,omitempty"`
+	Status            HookDeliveryStatus `json:"status,omitempty"`
+} // @name HookDelivery
+
+//+kubebuilder:object:root=true
+
+// @Description List of hook deliveries
+type HookDeliveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HookDelivery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HookDelivery{}, &HookDeliveryList{})
+}
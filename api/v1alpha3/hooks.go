@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2025 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -31,39 +31,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var validEventTypes = []string{"CREATE", "UPDATE", "DELETE"}
+// Lifecycle events, sent by the tenant controller once a tenant has been
+// provisioned, once it has been deprovisioned, and when it fails with an
+// error that will not go away by retrying. They are always delivered
+// asynchronously, as a HookDelivery, even to blocking hooks.
+const (
+	EventProvisioned   = "PROVISIONED"
+	EventDeprovisioned = "DEPROVISIONED"
+	EventFailed        = "FAILED"
+)
 
-var HooksClient client.Client
+var validEventTypes = []string{"CREATE", "UPDATE", "DELETE", EventProvisioned, EventDeprovisioned, EventFailed}
 
-type TenantEventPayload struct {
-	TenantSpec TenantSpec `json:"tenantspec"`
-	EventType  string     `json:"eventtype"`
-}
+// The namespace of GlobalTenantHooks, and the secrets they reference.
+const globalHooksNamespace = "tenants"
 
-func CallHooks(tenant *Tenant, log logr.Logger, event string) error {
+var HooksClient client.Client
+
+// CallHooks calls each tenant and global hook listening for event. The old
+// tenant is only set for UPDATE events, for the payload's previous spec.
+func CallHooks(tenant *Tenant, old *Tenant, log logr.Logger, event string) error {
+	payloadBytes, err := json.Marshal(NewTenantEventPayload(event, tenant, old))
+	if err != nil {
+		return err
+	}
 
 	for _, hook := range tenant.Spec.TenantHooks {
-		err := CallHook(hook, tenant, log, event)
+		err := callHook(hook, tenant.Namespace, tenant, log, event, payloadBytes)
 		if err != nil {
 			return err
 		}
 	}
 
-	globalHooks, err := getGlobalHooks(log)
+	globalHooks, err := getGlobalHooks(log, tenant, old)
 	if err != nil {
 		return err
 	}
 	for _, hook := range globalHooks {
-		err := CallHook(hook, tenant, log, event)
+		err := callHook(hook, globalHooksNamespace, tenant, log, event, payloadBytes)
 		if err != nil {
 			return err
 		}
@@ -72,71 +90,129 @@ func CallHooks(tenant *Tenant, log logr.Logger, event string) error {
 	return nil
 }
 
-func CallHook(hook TenantHook, tenant *Tenant, log logr.Logger, event string) error {
-	err := validateEventType(log, hook.EventTypes)
+// QueueLifecycleHooks queues a lifecycle event, with the tenant's status,
+// for delivery to each tenant and global hook listening for it.
+func QueueLifecycleHooks(ctx context.Context, tenant *Tenant, log logr.Logger, event string) error {
+	payloadBytes, err := json.Marshal(NewTenantEventPayload(event, tenant, nil))
 	if err != nil {
 		return err
 	}
 
-	if !Contains(hook.EventTypes, event) {
-		return nil
+	globalHooks, err := getGlobalHooks(log, tenant, nil)
+	if err != nil {
+		return err
 	}
+	queue := func(hook TenantHook, namespace string) error {
+		if !hookFiresFor(hook, event) {
+			return nil
+		}
+		return queueHookDelivery(ctx, hook, namespace, tenant, event, payloadBytes)
+	}
+	for _, hook := range tenant.Spec.TenantHooks {
+		if err := queue(hook, tenant.Namespace); err != nil {
+			return err
+		}
+	}
+	for _, hook := range globalHooks {
+		if err := queue(hook, globalHooksNamespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	payload := TenantEventPayload{}
-	payload.EventType = event
-	payload.TenantSpec = tenant.Spec
-	payloadBytes, err := json.Marshal(payload)
+// CallHook calls a blocking hook, returning an error if the hook fails
+// so the tenant operation is rejected. Notify hooks are not called here,
+// but queued as a HookDelivery for the HookDelivery controller.
+func CallHook(hook TenantHook, tenant *Tenant, log logr.Logger, event string) error {
+	payloadBytes, err := json.Marshal(NewTenantEventPayload(event, tenant, nil))
 	if err != nil {
 		return err
 	}
+	return callHook(hook, tenant.Namespace, tenant, log, event, payloadBytes)
+}
 
-	req, err := http.NewRequest(http.MethodPost, hook.Url, bytes.NewBuffer(payloadBytes))
+// callHook calls a hook defined in namespace, which is where its secret
+// (if any) is read from, with the event's payload.
+func callHook(hook TenantHook, namespace string, tenant *Tenant, log logr.Logger, event string, payloadBytes []byte) error {
+	err := validateEventType(log, hook.EventTypes)
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Content-Length", strconv.FormatInt(req.ContentLength, 10))
 
-	blockText := ""
-	if hook.BlockingCall {
-		blockText = "Blocking"
-	} else {
-		blockText = "Notify"
+	if !hookFiresFor(hook, event) {
+		return nil
+	}
+
+	ctx := context.Background()
+	if !hook.BlockingCall {
+		err = queueHookDelivery(ctx, hook, namespace, tenant, event, payloadBytes)
+		if err != nil {
+			// "Notify" hooks do not cause tenant operations to fail
+			Log.Error(err, fmt.Sprintf("Failed to queue delivery to '%s' hook at url %s", hook.Name, hook.Url))
+		}
+		return nil
 	}
 
-	Log.Info(fmt.Sprintf("Calling hook named '%s' at url %s (%s)", hook.Name, hook.Url, blockText))
+	Log.Info(fmt.Sprintf("Calling hook named '%s' at url %s (Blocking)", hook.Name, hook.Url))
+	err = DeliverHook(ctx, hook, namespace, event, payloadBytes)
+	if err != nil {
+		return fmt.Errorf("Blocking call to '%s' hook at url %s failed: %w", hook.Name, hook.Url, err)
+	}
+	Log.Info(fmt.Sprintf("Blocking call to '%s' hook at url %s called successfully", hook.Name, hook.Url))
+	return nil
+}
 
+// DeliverHook posts an event payload to a hook defined in namespace,
+// returning an error unless the hook responds with a 2xx status.
+func DeliverHook(ctx context.Context, hook TenantHook, namespace string, event string, payload []byte) error {
+	ctx = metrics.WithOperation(ctx, event)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Length", strconv.FormatInt(req.ContentLength, 10))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Block", strconv.FormatBool(hook.BlockingCall))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient, err := authorizeHook(ctx, hook, namespace, req, payload)
+	if err != nil {
+		return err
+	}
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
-		if hook.BlockingCall {
-			return err
-		} else {
-			// "Notify" hooks do not cause tenant operations to fail
-			Log.Info(fmt.Sprintf("%s call to '%s' hook at url %s returned an error: %s", blockText, hook.Name, hook.Url, err.Error()))
-			return nil
-		}
+		return err
 	}
-
 	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		if hook.BlockingCall {
-			return fmt.Errorf("%s call to '%s' hook at url %s returned a non-200 response code: %d", blockText, hook.Name, hook.Url, resp.StatusCode)
-		} else {
-			// "Notify" hooks do not cause tenant operations to fail
-			Log.Info(fmt.Sprintf("%s call to '%s' hook at url %s returned a non-200 response code: %d", blockText, hook.Name, hook.Url, resp.StatusCode))
-		}
-	} else {
-		Log.Info(fmt.Sprintf("%s call to '%s' hook at url %s called successfully", blockText, hook.Name, hook.Url))
+		return fmt.Errorf("hook returned a non-200 response code: %d", resp.StatusCode)
 	}
-
 	return nil
 }
 
+// authorizeHook adds the credentials from the hook's secret (if any) to
+// req, returning the client to send it with.
+func authorizeHook(ctx context.Context, hook TenantHook, namespace string, req *http.Request, body []byte) (*http.Client, error) {
+	if hook.SecretName == "" {
+		return newHookHttpClient(nil), nil
+	}
+	creds, err := getHookCredentials(ctx, namespace, hook.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	creds.authorize(req, body, time.Now())
+	return newHookHttpClient(creds.tlsConfig), nil
+}
+
+// hookFiresFor returns true if the hook is called for the event type.
+func hookFiresFor(hook TenantHook, event string) bool {
+	return Contains(hook.EventTypes, event)
+}
+
 func validateEventType(log logr.Logger, events []string) error {
 	for _, event := range events {
 		if !Contains(validEventTypes, event) {
@@ -146,14 +222,24 @@ func validateEventType(log logr.Logger, events []string) error {
 	return nil
 }
 
-func getGlobalHooks(log logr.Logger) ([]TenantHook, error) {
+// getGlobalHooks returns the global hooks whose filters match an event for
+// tenant. The old tenant is only set for UPDATE events.
+func getGlobalHooks(log logr.Logger, tenant *Tenant, old *Tenant) ([]TenantHook, error) {
 	webhookList, err := getGlobalHooksList(log)
 	if err != nil {
 		return nil, err
 	}
 	globalHooks := []TenantHook{}
 	for _, webhook := range webhookList.Items {
-		globalHooks = append(globalHooks, webhook.Spec)
+		matches, err := webhook.Spec.Matches(tenant, old)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			log.V(1).Info("Skipping global hook not matching tenant", "hook", webhook.Spec.Name, "tenant", tenant.Name)
+			continue
+		}
+		globalHooks = append(globalHooks, webhook.Spec.TenantHook)
 	}
 	return globalHooks, nil
 }
@@ -163,7 +249,7 @@ func getGlobalHooksList(log logr.Logger) (*GlobalTenantHookList, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	err := HooksClient.List(ctx, &webhookList, &client.ListOptions{
-		Namespace: "tenants",
+		Namespace: globalHooksNamespace,
 	})
 
 	if err != nil {
@@ -0,0 +1,70 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Failure policies for a tenant.
+const (
+	FailurePolicyRetry    = "retry"
+	FailurePolicyRollback = "rollback"
+)
+
+// Kinds of backend resources recorded in the step journal.
+const (
+	JournalKindNamespace     = "Namespace"
+	JournalKindHsmPartition  = "HsmPartition"
+	JournalKindHsmGroup      = "HsmGroup"
+	JournalKindKeycloakGroup = "KeycloakGroup"
+	JournalKindVaultTransit  = "VaultTransit"
+)
+
+// RecordCreated adds a backend resource created by a provisioning step to
+// the step journal, unless it is already there.
+func (t *Tenant) RecordCreated(step string, kind string, name string) {
+	for _, entry := range t.Status.Journal {
+		if entry.Kind == kind && entry.Name == name {
+			return
+		}
+	}
+	t.Status.Journal = append(t.Status.Journal, TenantJournalEntry{
+		Step:       step,
+		Kind:       kind,
+		Name:       name,
+		Generation: t.Generation,
+		Time:       metav1.Now(),
+	})
+}
+
+// RollbackOnFailure returns true if the backend resources created for the
+// tenant are deleted again when provisioning fails. That is only the case
+// with the rollback failure policy, and until the tenant is first deployed.
+func (t *Tenant) RollbackOnFailure() bool {
+	return t.Spec.FailurePolicy == FailurePolicyRollback && t.Status.ObservedGeneration == 0
+}
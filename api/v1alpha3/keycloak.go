@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -31,22 +31,16 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
-	"strings"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/config"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/go-logr/logr"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/keycloak"
 )
 
 type KeycloakGroup struct {
@@ -67,7 +61,7 @@ func listKeycloakGroups(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 		return result, nil, err
 	}
 
-	keycloakUrl := fmt.Sprintf("%s/admin/realms/shasta/groups", getKeycloakBase())
+	keycloakUrl := fmt.Sprintf("%s/admin/realms/%s/groups", getKeycloakBase(), getKeycloakRealm())
 
 	result, keycloakGroupBytes, err := buildKeycloakGroupPayload(log, t)
 	if err != nil {
@@ -82,7 +76,7 @@ func listKeycloakGroups(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient := KeycloakHttpClient()
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return ctrl.Result{}, nil, err
@@ -91,7 +85,7 @@ func listKeycloakGroups(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return ctrl.Result{}, nil, errors.New("keycloak returned a non-200 response listing groups")
+		return ctrl.Result{}, nil, &keycloak.Error{Op: "listing groups", StatusCode: resp.StatusCode}
 	}
 
 	body, _ := ioutil.ReadAll(resp.Body)
@@ -112,7 +106,7 @@ func listKeycloakRealmRoles(ctx context.Context, log logr.Logger, roleName strin
 		return result, nil, err
 	}
 
-	keycloakUrl := fmt.Sprintf("%s/admin/realms/shasta/roles?name=%s", getKeycloakBase(), roleName)
+	keycloakUrl := fmt.Sprintf("%s/admin/realms/%s/roles?name=%s", getKeycloakBase(), getKeycloakRealm(), roleName)
 
 	keycloakRole := KeycloakRole{}
 	keycloakRoleBytes, err := json.Marshal(keycloakRole)
@@ -127,7 +121,7 @@ func listKeycloakRealmRoles(ctx context.Context, log logr.Logger, roleName strin
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient := KeycloakHttpClient()
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return ctrl.Result{}, nil, err
@@ -136,7 +130,7 @@ func listKeycloakRealmRoles(ctx context.Context, log logr.Logger, roleName strin
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return ctrl.Result{}, nil, errors.New("keycloak returned a non-200 response listing groups")
+		return ctrl.Result{}, nil, &keycloak.Error{Op: "listing roles", StatusCode: resp.StatusCode}
 	}
 
 	body, _ := ioutil.ReadAll(resp.Body)
@@ -170,12 +164,12 @@ func getGroupId(ctx context.Context, log logr.Logger, t *Tenant) string {
 	var groupId string
 	_, groupList, err := listKeycloakGroups(ctx, log, t)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Failed to get groupid for keycloak group: %s", getKeycloakGroupName(t.Spec.TenantName)))
+		log.Error(err, fmt.Sprintf("Failed to get groupid for keycloak group: %s", GetKeycloakGroupName(t.Spec.TenantName)))
 		return groupId
 	}
 
 	for _, group := range groupList {
-		if group.Name == getKeycloakGroupName(t.Spec.TenantName) {
+		if group.Name == GetKeycloakGroupName(t.Spec.TenantName) {
 			groupId = group.Id
 			break
 		}
@@ -185,21 +179,21 @@ func getGroupId(ctx context.Context, log logr.Logger, t *Tenant) string {
 
 func AssignRoleToGroup(ctx context.Context, log logr.Logger, t *Tenant, roleName string, token string) (ctrl.Result, error) {
 
-	log.Info(fmt.Sprintf("Ensuring Keycloak realm role (%s) is assigned to Keycloak group: %s", roleName, getKeycloakGroupName(t.Spec.TenantName)))
+	log.Info(fmt.Sprintf("Ensuring Keycloak realm role (%s) is assigned to Keycloak group: %s", roleName, GetKeycloakGroupName(t.Spec.TenantName)))
 
 	groupId := getGroupId(ctx, log, t)
 	if len(groupId) <= 0 {
-		log.Info(fmt.Sprintf("Failed to get groupID assigning realm role (%s) to Keycloak group: %s", roleName, getKeycloakGroupName(t.Spec.TenantName)))
+		log.Info(fmt.Sprintf("Failed to get groupID assigning realm role (%s) to Keycloak group: %s", roleName, GetKeycloakGroupName(t.Spec.TenantName)))
 		return ctrl.Result{}, nil
 	}
 
 	roleId := getRoleId(ctx, log, roleName)
 	if len(roleId) <= 0 {
-		log.Info(fmt.Sprintf("Failed to get roleID assigning realm role (%s) to Keycloak group: %s", roleName, getKeycloakGroupName(t.Spec.TenantName)))
+		log.Info(fmt.Sprintf("Failed to get roleID assigning realm role (%s) to Keycloak group: %s", roleName, GetKeycloakGroupName(t.Spec.TenantName)))
 		return ctrl.Result{}, nil
 	}
 
-	keycloakUrl := fmt.Sprintf("%s/admin/realms/shasta/groups/%s/role-mappings/realm", getKeycloakBase(), groupId)
+	keycloakUrl := fmt.Sprintf("%s/admin/realms/%s/groups/%s/role-mappings/realm", getKeycloakBase(), getKeycloakRealm(), groupId)
 	result, keycloakRoleBytes, err := buildKeycloakRolePayload(log, roleName, roleId)
 	if err != nil {
 		return result, err
@@ -213,7 +207,7 @@ func AssignRoleToGroup(ctx context.Context, log logr.Logger, t *Tenant, roleName
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient := KeycloakHttpClient()
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -222,10 +216,10 @@ func AssignRoleToGroup(ctx context.Context, log logr.Logger, t *Tenant, roleName
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info(fmt.Sprintf("Assigned Keycloak realm role (%s) to Keycloak group: %s", roleName, getKeycloakGroupName(t.Spec.TenantName)))
+		log.Info(fmt.Sprintf("Assigned Keycloak realm role (%s) to Keycloak group: %s", roleName, GetKeycloakGroupName(t.Spec.TenantName)))
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{}, fmt.Errorf("keycloak returned a non-200 response assigning %s role", roleName)
+	return ctrl.Result{}, &keycloak.Error{Op: fmt.Sprintf("assigning %s role", roleName), StatusCode: resp.StatusCode}
 
 }
 
@@ -237,8 +231,8 @@ func UpdateKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	}
 
 	for _, group := range groupList {
-		if group.Name == getKeycloakGroupName(t.Spec.TenantName) {
-			log.Info("Keycloak group already exists: " + getKeycloakGroupName(t.Spec.TenantName))
+		if group.Name == GetKeycloakGroupName(t.Spec.TenantName) {
+			log.Info("Keycloak group already exists: " + GetKeycloakGroupName(t.Spec.TenantName))
 			return ctrl.Result{}, nil
 		}
 	}
@@ -247,7 +241,7 @@ func UpdateKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	if err != nil {
 		return result, err
 	}
-	keycloakUrl := fmt.Sprintf("%s/admin/realms/shasta/groups", getKeycloakBase())
+	keycloakUrl := fmt.Sprintf("%s/admin/realms/%s/groups", getKeycloakBase(), getKeycloakRealm())
 
 	result, keycloakGroupBytes, err := buildKeycloakGroupPayload(log, t)
 	if err != nil {
@@ -262,7 +256,7 @@ func UpdateKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient := KeycloakHttpClient()
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -271,14 +265,15 @@ func UpdateKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Created Keycloak group: " + getKeycloakGroupName(t.Spec.TenantName))
+		log.Info("Created Keycloak group: " + GetKeycloakGroupName(t.Spec.TenantName))
+		t.RecordCreated(ConditionKeycloakReady, JournalKindKeycloakGroup, GetKeycloakGroupName(t.Spec.TenantName))
 		result, err = AssignRoleToGroup(ctx, log, t, "tenant-admin", token)
 		if err != nil {
 			return result, err
 		}
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{}, errors.New("keycloak returned a non-200 response creating/updating group")
+	return ctrl.Result{}, &keycloak.Error{Op: "creating/updating group", StatusCode: resp.StatusCode}
 }
 
 func DeleteKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.Result, error) {
@@ -286,7 +281,7 @@ func DeleteKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	groupId := getGroupId(ctx, log, t)
 
 	if len(groupId) <= 0 {
-		log.Info("Keycloak group already deleted: " + getKeycloakGroupName(t.Spec.TenantName))
+		log.Info("Keycloak group already deleted: " + GetKeycloakGroupName(t.Spec.TenantName))
 		return ctrl.Result{}, nil
 	}
 
@@ -295,7 +290,7 @@ func DeleteKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 		return result, err
 	}
 
-	keycloakUrl := fmt.Sprintf("%s/admin/realms/shasta/groups/%s", getKeycloakBase(), groupId)
+	keycloakUrl := fmt.Sprintf("%s/admin/realms/%s/groups/%s", getKeycloakBase(), getKeycloakRealm(), groupId)
 
 	req, err := http.NewRequest(http.MethodDelete, keycloakUrl, nil)
 	if err != nil {
@@ -305,7 +300,7 @@ func DeleteKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	HTTPClient := NewHttpClient()
+	HTTPClient := KeycloakHttpClient()
 	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -314,53 +309,29 @@ func DeleteKeycloakGroup(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		log.Info("Deleted Keycloak group: " + getKeycloakGroupName(t.Spec.TenantName))
+		log.Info("Deleted Keycloak group: " + GetKeycloakGroupName(t.Spec.TenantName))
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{}, errors.New("keycloak returned a non-200 response deleting group")
+	return ctrl.Result{}, &keycloak.Error{Op: "deleting group", StatusCode: resp.StatusCode}
 }
 
+// GetToken returns a cached token for the master realm if masterAuth is
+// set, otherwise for the cluster realm.
 func GetToken(ctx context.Context, log logr.Logger, masterAuth bool) (ctrl.Result, string, error) {
-
-	var data url.Values
-	var keycloakUrl string
-	var res reconcile.Result
-	var err error
+	configMu.RLock()
+	tokens := clusterTokens
 	if masterAuth {
-		keycloakUrl = fmt.Sprintf("%s/realms/master/protocol/openid-connect/token", getKeycloakBase())
-		res, data, err = getMasterTokenUrlValues(ctx)
-
-	} else {
-		keycloakUrl = fmt.Sprintf("%s/realms/shasta/protocol/openid-connect/token", getClusterKeycloakBase())
-		res, data, err = getTokenUrlValues(ctx)
-	}
-	if err != nil {
-		return res, "", err
+		tokens = masterTokens
 	}
-	req, err := http.NewRequest(http.MethodPost, keycloakUrl, strings.NewReader(data.Encode()))
-	if err != nil {
-		return ctrl.Result{}, "", err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add("Content-Length", strconv.Itoa(len(data.Encode())))
-	HTTPClient := NewHttpClient()
-
-	resp, err := HTTPClient.Do(req)
+	configMu.RUnlock()
+	token, err := tokens.Token(ctx)
 	if err != nil {
+		log.Error(err, "Failed to get token from keycloak")
 		return ctrl.Result{}, "", err
 	}
-
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		log.Error(err, fmt.Sprintf("Failed to get token from keycloak, http code: %d", resp.StatusCode))
-		return ctrl.Result{Requeue: true}, "", err
-	}
-
-	var result map[string]string
-	json.NewDecoder(resp.Body).Decode(&result)
-	return ctrl.Result{}, result["access_token"], nil
-
+	return ctrl.Result{}, token, nil
 }
+
 func buildKeycloakRolePayload(log logr.Logger, roleName string, roleId string) (ctrl.Result, []byte, error) {
 
 	keycloakRole := KeycloakRole{}
@@ -377,7 +348,7 @@ func buildKeycloakRolePayload(log logr.Logger, roleName string, roleId string) (
 func buildKeycloakGroupPayload(log logr.Logger, t *Tenant) (ctrl.Result, []byte, error) {
 
 	keycloakGroup := KeycloakGroup{}
-	keycloakGroup.Name = getKeycloakGroupName(t.Spec.TenantName)
+	keycloakGroup.Name = GetKeycloakGroupName(t.Spec.TenantName)
 	keycloakGroup.Path = "/" + keycloakGroup.Name
 	keycloakGroupBytes, err := json.Marshal(keycloakGroup)
 	if err != nil {
@@ -386,31 +357,25 @@ func buildKeycloakGroupPayload(log logr.Logger, t *Tenant) (ctrl.Result, []byte,
 	return ctrl.Result{}, keycloakGroupBytes, err
 }
 
-func getMasterTokenUrlValues(ctx context.Context) (ctrl.Result, url.Values, error) {
-	client, err := client.New(config.GetConfigOrDie(), client.Options{})
+func getMasterTokenUrlValues(ctx context.Context) (url.Values, error) {
+	foundSecret, err := keycloakSecrets.get(ctx, masterAdminSecret())
 	if err != nil {
-		return ctrl.Result{}, nil, err
+		return nil, err
 	}
 
-	foundSecret := &corev1.Secret{}
-	err = client.Get(ctx, types.NamespacedName{Name: "keycloak-master-admin-auth", Namespace: "services"}, foundSecret)
+	_, clientId, err := decodeSecretValue(foundSecret.Data, "client-id")
 	if err != nil {
-		return ctrl.Result{}, nil, err
+		return nil, err
 	}
 
-	result, clientId, err := decodeSecretValue(foundSecret.Data, "client-id")
+	_, username, err := decodeSecretValue(foundSecret.Data, "user")
 	if err != nil {
-		return result, nil, err
+		return nil, err
 	}
 
-	result, username, err := decodeSecretValue(foundSecret.Data, "user")
+	_, password, err := decodeSecretValue(foundSecret.Data, "password")
 	if err != nil {
-		return result, nil, err
-	}
-
-	result, password, err := decodeSecretValue(foundSecret.Data, "password")
-	if err != nil {
-		return result, nil, err
+		return nil, err
 	}
 
 	data := url.Values{}
@@ -418,35 +383,29 @@ func getMasterTokenUrlValues(ctx context.Context) (ctrl.Result, url.Values, erro
 	data.Set("grant_type", "password")
 	data.Set("username", username)
 	data.Set("password", password)
-	return ctrl.Result{}, data, nil
+	return data, nil
 }
 
-func getTokenUrlValues(ctx context.Context) (ctrl.Result, url.Values, error) {
-	client, err := client.New(config.GetConfigOrDie(), client.Options{})
+func getTokenUrlValues(ctx context.Context) (url.Values, error) {
+	foundSecret, err := keycloakSecrets.get(ctx, adminClientSecret())
 	if err != nil {
-		return ctrl.Result{}, nil, err
+		return nil, err
 	}
 
-	foundSecret := &corev1.Secret{}
-	err = client.Get(ctx, types.NamespacedName{Name: "admin-client-auth", Namespace: "default"}, foundSecret)
+	_, clientSecret, err := decodeSecretValue(foundSecret.Data, "client-secret")
 	if err != nil {
-		return ctrl.Result{}, nil, err
+		return nil, err
 	}
-
-	result, clientSecret, err := decodeSecretValue(foundSecret.Data, "client-secret")
+	_, clientId, err := decodeSecretValue(foundSecret.Data, "client-id")
 	if err != nil {
-		return result, nil, err
-	}
-	result, clientId, err := decodeSecretValue(foundSecret.Data, "client-id")
-	if err != nil {
-		return result, nil, err
+		return nil, err
 	}
 
 	data := url.Values{}
 	data.Set("client_id", clientId)
 	data.Set("grant_type", "client_credentials")
 	data.Set("client_secret", clientSecret)
-	return ctrl.Result{}, data, nil
+	return data, nil
 }
 
 func decodeSecretValue(data map[string][]byte, key string) (ctrl.Result, string, error) {
@@ -458,14 +417,38 @@ func decodeSecretValue(data map[string][]byte, key string) (ctrl.Result, string,
 	return ctrl.Result{}, string(decStr), nil
 }
 
-func getKeycloakGroupName(tenantName string) string {
+// GetKeycloakGroupName returns the name of the Keycloak group whose members
+// administer the tenant.
+func GetKeycloakGroupName(tenantName string) string {
 	return tenantName + "-tenant-admin"
 }
 
 func getKeycloakBase() string {
-	return "http://keycloak.services:8080/keycloak"
+	return currentConfig().Keycloak.URL
+}
+
+// getKeycloakRealm returns the realm tenant groups are managed in.
+func getKeycloakRealm() string {
+	return currentConfig().Keycloak.Realm
+}
+
+// GetKeycloakCertsUrl returns the URL of the cluster realm's token signing keys.
+func GetKeycloakCertsUrl() string {
+	return fmt.Sprintf("%s/realms/%s/protocol/openid-connect/certs", getKeycloakBase(), getKeycloakRealm())
+}
+
+// GetKeycloakIssuers returns the issuers of realm tokens. Keycloak names
+// the issuer after the URL a token was requested from, so tokens from the
+// API gateway and from the Keycloak service are both accepted.
+func GetKeycloakIssuers() []string {
+	keycloak := currentConfig().Keycloak
+	return []string{
+		fmt.Sprintf("%s/realms/%s", keycloak.GatewayURL, keycloak.Realm),
+		fmt.Sprintf("%s/realms/%s", keycloak.URL, keycloak.Realm),
+	}
 }
 
-func getClusterKeycloakBase() string {
-	return fmt.Sprintf("https://%s/keycloak", GetApiGateway())
+// GetKeycloakClientID returns the client tenant API tokens must be for.
+func GetKeycloakClientID() string {
+	return currentConfig().Keycloak.ClientID
 }
@@ -0,0 +1,88 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/metrics"
+)
+
+// operationRule labels requests whose path contains substr as op.
+type operationRule struct {
+	substr string
+	op     string
+}
+
+// Rules are checked in order, so more specific paths come first.
+var (
+	hsmOperations = []operationRule{
+		{"/hsm/v2/groups", "groups"},
+		{"/hsm/v2/partitions", "partitions"},
+		{"/hsm/v2/State/Components", "components"},
+	}
+	pcsOperations = []operationRule{
+		{"/v1/power-status", "power_status"},
+		{"/v1/transitions", "transitions"},
+	}
+	keycloakOperations = []operationRule{
+		{"/protocol/openid-connect/token", "token"},
+		{"/protocol/openid-connect/certs", "certs"},
+		{"/role-mappings", "role_mappings"},
+		{"/roles", "roles"},
+		{"/groups", "groups"},
+	}
+	vaultOperations = []operationRule{
+		{"/v1/sys/mounts", "mounts"},
+		{"/v1/sys/policy", "policies"},
+		{"/v1/auth/kubernetes/login", "login"},
+		{"/v1/auth/kubernetes/role", "roles"},
+		{"/keys/", "keys"},
+	}
+)
+
+func operationFor(rules []operationRule) metrics.OperationFunc {
+	return func(req *http.Request) string {
+		for _, rule := range rules {
+			if strings.Contains(req.URL.Path, rule.substr) {
+				return rule.op
+			}
+		}
+		return "other"
+	}
+}
+
+// Group and partition membership changes are labeled separately from
+// the groups and partitions themselves, e.g. group_members.
+func hsmOperation(req *http.Request) string {
+	op := operationFor(hsmOperations)(req)
+	if strings.Contains(req.URL.Path, "/members") {
+		return strings.TrimSuffix(op, "s") + "_members"
+	}
+	return op
+}
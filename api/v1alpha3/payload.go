@@ -0,0 +1,138 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TenantEventPayloadVersion is the version of the TenantEventPayload
+// format. It changes when fields are removed or change meaning, not when
+// fields are added.
+const TenantEventPayloadVersion = "2"
+
+// @Description The tenant object a hook event is for
+type TenantEventMetadata struct {
+	Name       string    `json:"name" example:"vcluster-blue"`
+	Namespace  string    `json:"namespace" example:"tenants"`
+	UID        types.UID `json:"uid,omitempty" swaggertype:"string" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Generation int64     `json:"generation,omitempty"`
+} // @name TenantEventMetadata
+
+// @Description The xnames added to and removed from a tenant resource by a hook event
+type TenantResourceChange struct {
+	Type          string   `json:"type" example:"compute"`
+	AddedXnames   []string `json:"addedxnames,omitempty" example:"x0c3s5b0n0"`
+	RemovedXnames []string `json:"removedxnames,omitempty" example:"x0c3s6b0n0"`
+} // @name TenantResourceChange
+
+// @Description The payload POSTed to hooks for a tenant event
+type TenantEventPayload struct {
+	// The payload format version, see TenantEventPayloadVersion.
+	Version string `json:"version" example:"2"`
+	// A unique ID for the event. Every hook gets the same ID for an event,
+	// and retried deliveries keep it, so receivers can ignore duplicates.
+	EventID   string `json:"eventid" example:"9b2c6f1e-8f4d-4a7e-b5c3-2d1e0f9a8b7c"`
+	EventType string `json:"eventtype" example:"CREATE,UPDATE,DELETE,PROVISIONED,DEPROVISIONED,FAILED,PING"`
+	// When the event occurred, in RFC 3339 format.
+	Timestamp string              `json:"timestamp" example:"2026-01-02T15:04:05Z"`
+	Metadata  TenantEventMetadata `json:"metadata"`
+	// The tenant spec, or for DELETE events the spec being deleted.
+	TenantSpec TenantSpec `json:"tenantspec"`
+	// The spec before the change, for UPDATE events.
+	OldTenantSpec *TenantSpec `json:"oldtenantspec,omitempty"`
+	// The xnames added to or removed from each resource type by the event.
+	Changes      []TenantResourceChange `json:"changes,omitempty"`
+	TenantStatus *TenantStatus          `json:"tenantstatus,omitempty"`
+} // @name TenantEventPayload
+
+// NewTenantEventPayload returns the payload for an event for tenant. The
+// old tenant is only used for UPDATE events, and may be nil.
+func NewTenantEventPayload(event string, tenant *Tenant, old *Tenant) TenantEventPayload {
+	payload := TenantEventPayload{
+		Version:   TenantEventPayloadVersion,
+		EventID:   uuid.New().String(),
+		EventType: event,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Metadata: TenantEventMetadata{
+			Name:       tenant.Name,
+			Namespace:  tenant.Namespace,
+			UID:        tenant.UID,
+			Generation: tenant.Generation,
+		},
+		TenantSpec:   tenant.Spec,
+		TenantStatus: tenant.Status.DeepCopy(),
+	}
+
+	switch event {
+	case "CREATE":
+		payload.Changes = resourceChanges(nil, tenant.ResolvedResources())
+	case "UPDATE":
+		if old != nil {
+			payload.OldTenantSpec = old.Spec.DeepCopy()
+			payload.Changes = resourceChanges(old.ResolvedResources(), tenant.ResolvedResources())
+		}
+	case "DELETE", EventDeprovisioned:
+		payload.Changes = resourceChanges(tenant.ResolvedResources(), nil)
+	}
+	return payload
+}
+
+// resourceChanges returns the xnames added and removed for each resource
+// type between the old and new resolved resources, omitting unchanged
+// types.
+func resourceChanges(old []TenantResource, new []TenantResource) []TenantResourceChange {
+	xnames := func(resources []TenantResource, resourceType string) []string {
+		if r := findResource(resources, resourceType); r != nil {
+			return r.Xnames
+		}
+		return nil
+	}
+
+	var resourceTypes []string
+	for _, r := range append(append([]TenantResource{}, old...), new...) {
+		resourceTypes = appendUnique(resourceTypes, []string{r.Type})
+	}
+
+	var changes []TenantResourceChange
+	for _, resourceType := range resourceTypes {
+		oldXnames := xnames(old, resourceType)
+		newXnames := xnames(new, resourceType)
+		change := TenantResourceChange{
+			Type:          resourceType,
+			AddedXnames:   Difference(newXnames, oldXnames),
+			RemovedXnames: Difference(oldXnames, newXnames),
+		}
+		if len(change.AddedXnames) > 0 || len(change.RemovedXnames) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
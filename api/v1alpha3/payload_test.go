@@ -0,0 +1,118 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewTenantEventPayload(t *testing.T) {
+	old := &Tenant{
+		Spec: TenantSpec{
+			TenantName: "vcluster-blue",
+			TenantResources: []TenantResource{
+				{Type: "compute", Xnames: []string{"x0c3s5b0n0", "x0c3s6b0n0"}},
+				{Type: "application", Xnames: []string{"x3000c0s19b1n0"}},
+			},
+		},
+	}
+	tenant := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", UID: "uid", Generation: 2},
+		Spec: TenantSpec{
+			TenantName: "vcluster-blue",
+			TenantResources: []TenantResource{
+				{Type: "compute", Xnames: []string{"x0c3s5b0n0", "x0c3s7b0n0"}},
+				{Type: "application", Xnames: []string{"x3000c0s19b1n0"}},
+			},
+		},
+		Status: TenantStatus{Phase: PhaseDeployed},
+	}
+
+	payload := NewTenantEventPayload("UPDATE", tenant, old)
+	if payload.Version != TenantEventPayloadVersion || payload.EventID == "" || payload.Timestamp == "" {
+		t.Errorf("unexpected payload header %+v", payload)
+	}
+	if payload.Metadata != (TenantEventMetadata{Name: "vcluster-blue", Namespace: "tenants", UID: "uid", Generation: 2}) {
+		t.Errorf("unexpected metadata %+v", payload.Metadata)
+	}
+	if payload.OldTenantSpec == nil || !reflect.DeepEqual(*payload.OldTenantSpec, old.Spec) {
+		t.Errorf("expected the old spec, got %+v", payload.OldTenantSpec)
+	}
+	if payload.TenantStatus == nil || payload.TenantStatus.Phase != PhaseDeployed {
+		t.Errorf("expected the tenant status, got %+v", payload.TenantStatus)
+	}
+	expected := []TenantResourceChange{{Type: "compute", AddedXnames: []string{"x0c3s7b0n0"}, RemovedXnames: []string{"x0c3s6b0n0"}}}
+	if !reflect.DeepEqual(payload.Changes, expected) {
+		t.Errorf("expected changes %+v, got %+v", expected, payload.Changes)
+	}
+
+	if other := NewTenantEventPayload("UPDATE", tenant, old); other.EventID == payload.EventID {
+		t.Errorf("expected a unique event ID, got %s twice", payload.EventID)
+	}
+
+	deleted := NewTenantEventPayload("DELETE", tenant, nil)
+	if len(deleted.Changes) != 2 || len(deleted.Changes[0].AddedXnames) != 0 || len(deleted.Changes[0].RemovedXnames) != 2 {
+		t.Errorf("expected every xname to be removed, got %+v", deleted.Changes)
+	}
+}
+
+func TestNewTenantEventPayloadResolvedXnames(t *testing.T) {
+	old := &Tenant{
+		Spec: TenantSpec{
+			TenantName: "vcluster-blue",
+			TenantResources: []TenantResource{
+				{Type: "compute", Xnames: []string{"x0c3s5b0n0"}, XnamePatterns: []string{"x1000c0s[0-1]b0n0"}},
+			},
+		},
+		Status: TenantStatus{
+			ResolvedXnames: []TenantResolvedXnames{{Type: "compute", Xnames: []string{"x1000c0s0b0n0"}}},
+		},
+	}
+	tenant := old.DeepCopy()
+	tenant.Status.ResolvedXnames[0].Xnames = []string{"x1000c0s0b0n0", "x1000c0s1b0n0"}
+
+	created := NewTenantEventPayload("CREATE", tenant, nil)
+	expected := []TenantResourceChange{{Type: "compute", AddedXnames: []string{"x0c3s5b0n0", "x1000c0s0b0n0", "x1000c0s1b0n0"}}}
+	if !reflect.DeepEqual(created.Changes, expected) {
+		t.Errorf("expected changes %+v, got %+v", expected, created.Changes)
+	}
+
+	updated := NewTenantEventPayload("UPDATE", tenant, old)
+	expected = []TenantResourceChange{{Type: "compute", AddedXnames: []string{"x1000c0s1b0n0"}}}
+	if !reflect.DeepEqual(updated.Changes, expected) {
+		t.Errorf("expected changes %+v, got %+v", expected, updated.Changes)
+	}
+
+	deleted := NewTenantEventPayload("DELETE", old, nil)
+	expected = []TenantResourceChange{{Type: "compute", RemovedXnames: []string{"x0c3s5b0n0", "x1000c0s0b0n0"}}}
+	if !reflect.DeepEqual(deleted.Changes, expected) {
+		t.Errorf("expected changes %+v, got %+v", expected, deleted.Changes)
+	}
+}
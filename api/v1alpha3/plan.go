@@ -0,0 +1,203 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+// @Description The namespaces TAPMS will create and delete for a tenant
+type NamespacePlan struct {
+	Create []string `json:"create,omitempty" example:"vcluster-blue-slurm"`
+	Delete []string `json:"delete,omitempty" example:"vcluster-blue-user"`
+} // @name NamespacePlan
+
+// @Description The changes TAPMS will make to an HSM partition or group
+type HsmPlan struct {
+	// The HSM partition name or group label.
+	Name          string   `json:"name" example:"blue"`
+	Create        bool     `json:"create"`
+	AddMembers    []string `json:"addmembers,omitempty" example:"x0c3s5b0n0"`
+	RemoveMembers []string `json:"removemembers,omitempty" example:"x0c3s6b0n0"`
+} // @name HsmPlan
+
+// @Description The xnames TAPMS will power off, if they are not already off
+type PowerPlan struct {
+	Operation string   `json:"operation" example:"off"`
+	Xnames    []string `json:"xnames" example:"x0c3s5b0n0,x0c3s6b0n0"`
+} // @name PowerPlan
+
+// @Description The Keycloak group TAPMS will ensure exists for a tenant
+type KeycloakPlan struct {
+	Group  string `json:"group" example:"vcluster-blue-tenant-admin"`
+	Create bool   `json:"create"`
+} // @name KeycloakPlan
+
+// @Description The Vault transit engine TAPMS will ensure exists for a tenant
+type VaultPlan struct {
+	TransitName string `json:"transitname" example:"cray-tenant-550e8400-e29b-41d4-a716-446655440000"`
+	KeyName     string `json:"keyname" example:"key1"`
+	KeyType     string `json:"keytype" example:"rsa-3072"`
+	Create      bool   `json:"create"`
+} // @name VaultPlan
+
+// @Description A hook TAPMS will call for a tenant change
+type HookPlan struct {
+	Name         string `json:"name"`
+	Url          string `json:"url" example:"http://<url>:<port>"`
+	BlockingCall bool   `json:"blockingcall"`
+	// The admission event, or PROVISIONED once the plan has been applied.
+	EventType string `json:"eventtype" example:"CREATE,UPDATE,PROVISIONED"`
+} // @name HookPlan
+
+// @Description The changes TAPMS will make to apply a tenant spec
+type TenantPlan struct {
+	TenantName    string        `json:"tenantname" example:"vcluster-blue"`
+	EventType     string        `json:"eventtype" example:"CREATE,UPDATE"`
+	Namespaces    NamespacePlan `json:"namespaces"`
+	HsmPartitions []HsmPlan     `json:"hsmpartitions,omitempty"`
+	HsmGroups     []HsmPlan     `json:"hsmgroups,omitempty"`
+	PowerOff      []PowerPlan   `json:"poweroff,omitempty"`
+	Keycloak      KeycloakPlan  `json:"keycloak"`
+	// Only set if the tenant has KMS enabled.
+	Vault *VaultPlan `json:"vault,omitempty"`
+	Hooks []HookPlan `json:"hooks,omitempty"`
+} // @name TenantPlan
+
+// ComputeTenantPlan returns the changes the tenant reconciler will make
+// to apply the tenant spec, given the namespaces and HSM members applied
+// so far. Like the reconciler, it assumes the status reflects the HSM
+// partitions and groups, and that xname patterns and selectors have been
+// resolved by ResolveXnames; no external services are called.
+func ComputeTenantPlan(t *Tenant, globalHooks []TenantHook) *TenantPlan {
+	plan := &TenantPlan{
+		TenantName: t.Spec.TenantName,
+		EventType:  "UPDATE",
+	}
+	if t.CreationTimestamp.IsZero() {
+		plan.EventType = "CREATE"
+		plan.Namespaces.Create = append(plan.Namespaces.Create, t.Spec.TenantName)
+	}
+
+	for _, childNamespace := range Difference(t.Spec.ChildNamespaces, t.appliedChildNamespaces()) {
+		plan.Namespaces.Create = append(plan.Namespaces.Create, GetChildNamespaceName(t.Spec.TenantName, childNamespace))
+	}
+	for _, childNamespace := range DeletedChildNamespaces(t) {
+		plan.Namespaces.Delete = append(plan.Namespaces.Delete, GetChildNamespaceName(t.Spec.TenantName, childNamespace))
+	}
+
+	for _, resource := range t.ResolvedResources() {
+		if len(resource.HsmPartitionName) == 0 {
+			continue
+		}
+		partitionPlan := HsmPlan{Name: resource.HsmPartitionName}
+		if _, applied := t.appliedHsmMembers(HsmKindPartition, resource.HsmPartitionName); !applied {
+			partitionPlan.Create = true
+			partitionPlan.AddMembers = resource.Xnames
+		} else {
+			partitionPlan.AddMembers, partitionPlan.RemoveMembers = hsmMemberChanges(t, HsmKindPartition, resource.HsmPartitionName)
+		}
+		plan.HsmPartitions = addHsmPlan(plan.HsmPartitions, partitionPlan)
+	}
+
+	for _, resource := range t.ResolvedResources() {
+		if len(resource.HsmGroupLabel) == 0 {
+			continue
+		}
+		groupPlan := HsmPlan{Name: resource.HsmGroupLabel}
+		if _, applied := t.appliedHsmMembers(HsmKindGroup, resource.HsmGroupLabel); !applied {
+			groupPlan.Create = true
+			groupPlan.AddMembers = resource.Xnames
+		} else {
+			groupPlan.AddMembers, groupPlan.RemoveMembers = hsmMemberChanges(t, HsmKindGroup, resource.HsmGroupLabel)
+		}
+		plan.HsmGroups = addHsmPlan(plan.HsmGroups, groupPlan)
+	}
+	for _, removed := range removedHsmGroups(t) {
+		plan.HsmGroups = addHsmPlan(plan.HsmGroups, HsmPlan{Name: removed.Name, RemoveMembers: removed.Xnames})
+	}
+
+	xnamesByOperation := determinePowerChanges(t)
+	for _, operation := range []string{PowerPolicyOff, PowerPolicyForceOff} {
+		if len(xnamesByOperation[operation]) > 0 {
+			plan.PowerOff = append(plan.PowerOff, PowerPlan{Operation: operation, Xnames: xnamesByOperation[operation]})
+		}
+	}
+
+	plan.Keycloak = KeycloakPlan{
+		Group:  GetKeycloakGroupName(t.Spec.TenantName),
+		Create: plan.EventType == "CREATE",
+	}
+
+	if t.Spec.TenantKmsResource.Enabled {
+		transitName := transitEngineName(t)
+		if transitName == "" {
+			transitName = tapms_transit_prefix + "<generated-uuid>"
+		}
+		plan.Vault = &VaultPlan{
+			TransitName: transitName,
+			KeyName:     t.Spec.TenantKmsResource.KeyName,
+			KeyType:     t.Spec.TenantKmsResource.KeyType,
+			Create:      t.Status.TenantKmsStatus.TransitName == "",
+		}
+	}
+
+	for _, hook := range append(append([]TenantHook{}, t.Spec.TenantHooks...), globalHooks...) {
+		if hookFiresFor(hook, plan.EventType) {
+			plan.Hooks = append(plan.Hooks, HookPlan{Name: hook.Name, Url: hook.Url, BlockingCall: hook.BlockingCall, EventType: plan.EventType})
+		}
+		// Lifecycle events are always delivered asynchronously.
+		if hookFiresFor(hook, EventProvisioned) {
+			plan.Hooks = append(plan.Hooks, HookPlan{Name: hook.Name, Url: hook.Url, EventType: EventProvisioned})
+		}
+	}
+
+	return plan
+}
+
+// addHsmPlan adds the changes for a partition or group to plans, merging
+// them with any other changes to the same partition or group. Partitions
+// and groups without changes are left out.
+func addHsmPlan(plans []HsmPlan, hsmPlan HsmPlan) []HsmPlan {
+	for i := range plans {
+		if plans[i].Name == hsmPlan.Name {
+			plans[i].Create = plans[i].Create || hsmPlan.Create
+			plans[i].AddMembers = appendUnique(plans[i].AddMembers, hsmPlan.AddMembers)
+			plans[i].RemoveMembers = appendUnique(plans[i].RemoveMembers, hsmPlan.RemoveMembers)
+			return plans
+		}
+	}
+	if !hsmPlan.Create && len(hsmPlan.AddMembers) == 0 && len(hsmPlan.RemoveMembers) == 0 {
+		return plans
+	}
+	return append(plans, hsmPlan)
+}
+
+func appendUnique(slice []string, values []string) []string {
+	for _, value := range values {
+		if !Contains(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,125 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeTenantPlanCreate(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.Spec = TenantSpec{
+		TenantName:        "vcluster-blue",
+		ChildNamespaces:   []string{"slurm"},
+		TenantResources:   []TenantResource{{Type: "compute", Xnames: []string{"x1", "x2"}, HsmPartitionName: "blue", HsmGroupLabel: "blue"}},
+		TenantKmsResource: TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-3072"},
+		TenantHooks:       []TenantHook{{Name: "create", EventTypes: []string{"CREATE"}}, {Name: "delete", EventTypes: []string{"DELETE", "PROVISIONED"}}},
+	}
+
+	plan := ComputeTenantPlan(tenant, []TenantHook{{Name: "global", EventTypes: []string{"CREATE", "UPDATE"}}})
+	if plan.EventType != "CREATE" || !plan.Keycloak.Create || plan.Vault == nil || !plan.Vault.Create {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+	if !reflect.DeepEqual(plan.Namespaces.Create, []string{"vcluster-blue", "vcluster-blue-slurm"}) {
+		t.Errorf("unexpected namespaces %+v", plan.Namespaces)
+	}
+	expected := []HsmPlan{{Name: "blue", Create: true, AddMembers: []string{"x1", "x2"}}}
+	if !reflect.DeepEqual(plan.HsmPartitions, expected) || !reflect.DeepEqual(plan.HsmGroups, expected) {
+		t.Errorf("unexpected HSM changes %+v %+v", plan.HsmPartitions, plan.HsmGroups)
+	}
+	if len(plan.Hooks) != 3 || plan.Hooks[0].Name != "create" || plan.Hooks[1].Name != "delete" || plan.Hooks[1].EventType != EventProvisioned || plan.Hooks[2].Name != "global" {
+		t.Errorf("unexpected hooks %+v", plan.Hooks)
+	}
+}
+
+func TestComputeTenantPlanUpdate(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.CreationTimestamp = metav1.Now()
+	tenant.Spec = TenantSpec{
+		TenantName:      "vcluster-blue",
+		ChildNamespaces: []string{"slurm"},
+		TenantResources: []TenantResource{{Type: "compute", Xnames: []string{"x1", "x3"}, HsmPartitionName: "blue", HsmGroupLabel: "blue", PowerPolicy: PowerPolicyOff}},
+	}
+	tenant.Status = TenantStatus{
+		ChildNamespaces: []string{"vcluster-blue-slurm", "vcluster-blue-user"},
+		TenantResources: []TenantResource{
+			{Type: "compute", Xnames: []string{"x1", "x2"}, HsmPartitionName: "blue", HsmGroupLabel: "blue", PowerPolicy: PowerPolicyOff},
+			{Type: "application", Xnames: []string{"x9"}, HsmGroupLabel: "blue-uan"},
+		},
+	}
+
+	plan := ComputeTenantPlan(tenant, nil)
+	if plan.EventType != "UPDATE" || plan.Keycloak.Create || plan.Vault != nil || len(plan.Hooks) != 0 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+	if len(plan.Namespaces.Create) != 0 || !reflect.DeepEqual(plan.Namespaces.Delete, []string{"vcluster-blue-user"}) {
+		t.Errorf("unexpected namespaces %+v", plan.Namespaces)
+	}
+	expected := []HsmPlan{{Name: "blue", AddMembers: []string{"x3"}, RemoveMembers: []string{"x2"}}}
+	if !reflect.DeepEqual(plan.HsmPartitions, expected) {
+		t.Errorf("unexpected HSM partition changes %+v", plan.HsmPartitions)
+	}
+	expected = append(expected, HsmPlan{Name: "blue-uan", RemoveMembers: []string{"x9"}})
+	if !reflect.DeepEqual(plan.HsmGroups, expected) {
+		t.Errorf("unexpected HSM group changes %+v", plan.HsmGroups)
+	}
+	if !reflect.DeepEqual(plan.PowerOff, []PowerPlan{{Operation: PowerPolicyOff, Xnames: []string{"x2", "x3"}}}) {
+		t.Errorf("unexpected power changes %+v", plan.PowerOff)
+	}
+}
+
+func TestComputeTenantPlanPartiallyApplied(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.CreationTimestamp = metav1.Now()
+	tenant.Spec = TenantSpec{
+		TenantName:      "vcluster-blue",
+		ChildNamespaces: []string{"slurm"},
+		TenantResources: []TenantResource{{Type: "compute", Xnames: []string{"x1", "x3"}, HsmPartitionName: "blue", HsmGroupLabel: "blue"}},
+	}
+	// The partition was updated before the HSM group step failed.
+	tenant.Status = TenantStatus{
+		ChildNamespaces: []string{"vcluster-blue-slurm"},
+		TenantResources: []TenantResource{{Type: "compute", Xnames: []string{"x1", "x2"}, HsmPartitionName: "blue", HsmGroupLabel: "blue"}},
+		Applied: &TenantAppliedStatus{
+			ChildNamespaces: []string{"vcluster-blue-slurm"},
+			HsmPartitions:   []TenantHsmMembers{{Name: "blue", Xnames: []string{"x1", "x3"}}},
+			HsmGroups:       []TenantHsmMembers{{Name: "blue", Xnames: []string{"x1", "x2"}}},
+		},
+	}
+
+	plan := ComputeTenantPlan(tenant, nil)
+	if len(plan.HsmPartitions) != 0 {
+		t.Errorf("expected no HSM partition changes, got %+v", plan.HsmPartitions)
+	}
+	expected := []HsmPlan{{Name: "blue", AddMembers: []string{"x3"}, RemoveMembers: []string{"x2"}}}
+	if !reflect.DeepEqual(plan.HsmGroups, expected) {
+		t.Errorf("unexpected HSM group changes %+v", plan.HsmGroups)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/pcs"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Power policies for a tenant resource.
+const (
+	PowerPolicyOff      = pcs.OperationOff
+	PowerPolicyForceOff = pcs.OperationForceOff
+)
+
+// The power transition status recorded when PCS completed a transition
+// but some xnames failed to power off.
+const powerTransitionFailed = "failed"
+
+// The number of power transitions kept in the tenant status.
+const maxPowerTransitions = 10
+
+// PowerPollInterval is how often in-progress power transitions are checked.
+const PowerPollInterval = 15 * time.Second
+
+// PcsClient is the PCS client used by the tenant reconciler.
+// It is set by Configure, and may be replaced (e.g. with a fake) before
+// the manager is started.
+var PcsClient pcs.Client
+
+func pcsClient() pcs.Client {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return PcsClient
+}
+
+// HasPowerPolicy returns true if any current or previous resource of
+// the tenant has a power policy set.
+func HasPowerPolicy(t *Tenant) bool {
+	for _, resources := range [][]TenantResource{t.Spec.TenantResources, t.Status.TenantResources} {
+		for _, resource := range resources {
+			if len(resource.PowerPolicy) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PendingPowerTransitions returns the IDs of the power transitions PCS
+// is still working on.
+func PendingPowerTransitions(t *Tenant) []string {
+	var ids []string
+	for _, transition := range t.Status.PowerTransitions {
+		if !powerTransitionDone(transition) {
+			ids = append(ids, transition.TransitionID)
+		}
+	}
+	return ids
+}
+
+// EnsurePowerState powers off the xnames moving into or out of tenant
+// resources with a power policy set. Each PCS transition is recorded in
+// the tenant status and polled until it completes; the returned result
+// requeues while any transition is still in progress.
+func EnsurePowerState(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.Result, error) {
+	result, err := checkPowerTransitions(ctx, log, t)
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
+
+	xnamesByOperation := determinePowerChanges(t)
+	started := false
+	for _, operation := range []string{PowerPolicyOff, PowerPolicyForceOff} {
+		transitionID, err := ensureXnamesOff(ctx, log, t, xnamesByOperation[operation], operation)
+		if err != nil {
+			log.Error(err, "Failed to power off xname(s)")
+			return ctrl.Result{}, err
+		}
+		if len(transitionID) > 0 {
+			started = true
+		}
+	}
+	if started {
+		return ctrl.Result{RequeueAfter: PowerPollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// determinePowerChanges returns the xnames that were added to or removed
+// from a resource with a power policy, keyed by the operation to use.
+func determinePowerChanges(t *Tenant) map[string][]string {
+	xnamesByOperation := map[string][]string{}
+	add := func(operation string, xnames []string) {
+		if len(operation) == 0 {
+			return
+		}
+		for _, xname := range xnames {
+			if !Contains(xnamesByOperation[operation], xname) {
+				xnamesByOperation[operation] = append(xnamesByOperation[operation], xname)
+			}
+		}
+	}
+
+	specResources := t.ResolvedResources()
+
+	//
+	// This loop handles case where a resource group is removed.
+	//
+	for _, statResource := range t.Status.TenantResources {
+		if findResource(specResources, statResource.Type) == nil {
+			add(statResource.PowerPolicy, statResource.Xnames)
+		}
+	}
+
+	//
+	// This loop handles case where a resource group is added or
+	// its members changed.
+	//
+	for _, specResource := range specResources {
+		statResource := findResource(t.Status.TenantResources, specResource.Type)
+		if statResource == nil {
+			add(specResource.PowerPolicy, specResource.Xnames)
+			continue
+		}
+		add(specResource.PowerPolicy, Difference(statResource.Xnames, specResource.Xnames))
+		add(specResource.PowerPolicy, Difference(specResource.Xnames, statResource.Xnames))
+	}
+
+	return xnamesByOperation
+}
+
+func findResource(resources []TenantResource, resourceType string) *TenantResource {
+	for i := range resources {
+		if resources[i].Type == resourceType {
+			return &resources[i]
+		}
+	}
+	return nil
+}
+
+// ensureXnamesOff requests a power transition for any of the xnames that
+// are not already off, and returns its transition ID (or an empty
+// string if no transition was needed).
+func ensureXnamesOff(ctx context.Context, log logr.Logger, t *Tenant, xnames []string, operation string) (string, error) {
+	if len(xnames) == 0 {
+		return "", nil
+	}
+
+	powerStatus, err := pcsClient().GetPowerStatus(ctx, xnames)
+	if err != nil {
+		log.Error(err, "Failed to check power status")
+		return "", err
+	}
+
+	xnamesToPowerOff := make([]string, 0)
+	for _, state := range powerStatus.Status {
+		if !Contains(xnames, state.Xname) {
+			continue
+		}
+		log.Info(fmt.Sprintf("Current power status %s: %v", state.Xname, state.PowerState))
+		if state.PowerState != "off" {
+			xnamesToPowerOff = append(xnamesToPowerOff, state.Xname)
+		}
+	}
+	if len(xnamesToPowerOff) == 0 {
+		return "", nil
+	}
+
+	log.Info(fmt.Sprintf("Requesting power %s for %v", operation, xnamesToPowerOff))
+	output, err := pcsClient().CreateTransition(ctx, operation, xnamesToPowerOff)
+	if err != nil {
+		return "", err
+	}
+	log.Info(fmt.Sprintf("Power Transition ID: %s for xnames %v", output.TransitionID, xnamesToPowerOff))
+
+	addPowerTransition(t, TenantPowerTransition{
+		TransitionID:       output.TransitionID,
+		Operation:          operation,
+		Xnames:             xnamesToPowerOff,
+		Status:             pcs.TransitionStatusNew,
+		ObservedGeneration: t.Generation,
+	})
+	return output.TransitionID, nil
+}
+
+// checkPowerTransitions refreshes the status of any power transitions
+// still in progress. An error is returned for transitions that just
+// finished without powering off all of their xnames, so the power
+// state is checked (and the transition retried) on the next reconcile.
+func checkPowerTransitions(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.Result, error) {
+	pending := false
+	var failed []string
+	for i := range t.Status.PowerTransitions {
+		record := &t.Status.PowerTransitions[i]
+		if powerTransitionDone(*record) {
+			continue
+		}
+
+		transition, err := pcsClient().GetTransition(ctx, record.TransitionID)
+		if pcs.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Power transition %s no longer exists", record.TransitionID))
+			record.Status = pcs.TransitionStatusAborted
+			failed = append(failed, record.TransitionID)
+			continue
+		} else if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		record.Status = transition.TransitionStatus
+		if !transition.Done() {
+			pending = true
+			continue
+		}
+
+		record.FailedXnames = transition.FailedXnames()
+		if len(record.FailedXnames) > 0 {
+			record.Status = powerTransitionFailed
+		}
+		if record.Status != pcs.TransitionStatusCompleted {
+			failed = append(failed, record.TransitionID)
+		} else {
+			log.Info(fmt.Sprintf("Power transition %s completed for xnames %v", record.TransitionID, record.Xnames))
+		}
+	}
+
+	if len(failed) > 0 {
+		return ctrl.Result{}, fmt.Errorf("power transition(s) %v did not power off all xnames", failed)
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: PowerPollInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func powerTransitionDone(transition TenantPowerTransition) bool {
+	switch transition.Status {
+	case pcs.TransitionStatusCompleted, pcs.TransitionStatusAborted, powerTransitionFailed:
+		return true
+	}
+	return false
+}
+
+// addPowerTransition records a new power transition, dropping the
+// oldest finished transitions beyond maxPowerTransitions.
+func addPowerTransition(t *Tenant, transition TenantPowerTransition) {
+	transitions := append(t.Status.PowerTransitions, transition)
+	for len(transitions) > maxPowerTransitions && powerTransitionDone(transitions[0]) {
+		transitions = transitions[1:]
+	}
+	t.Status.PowerTransitions = transitions
+}
@@ -0,0 +1,134 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/pcs"
+	"github.com/go-logr/logr"
+)
+
+type fakePcsClient struct {
+	powerState  map[string]string
+	transitions map[string]*pcs.Transition
+	requests    []pcs.TransitionRequest
+}
+
+func (f *fakePcsClient) GetPowerStatus(ctx context.Context, xnames []string) (*pcs.PowerStatus, error) {
+	status := pcs.PowerStatus{}
+	for _, xname := range xnames {
+		status.Status = append(status.Status, pcs.XnamePowerState{Xname: xname, PowerState: f.powerState[xname]})
+	}
+	return &status, nil
+}
+
+func (f *fakePcsClient) CreateTransition(ctx context.Context, operation string, xnames []string) (*pcs.TransitionStartOutput, error) {
+	request := pcs.TransitionRequest{Operation: operation}
+	for _, xname := range xnames {
+		request.Location = append(request.Location, pcs.TransitionLocation{Xname: xname})
+	}
+	f.requests = append(f.requests, request)
+	id := operation + "-transition"
+	f.transitions[id] = &pcs.Transition{TransitionID: id, Operation: operation, TransitionStatus: pcs.TransitionStatusInProgress}
+	return &pcs.TransitionStartOutput{TransitionID: id, Operation: operation}, nil
+}
+
+func (f *fakePcsClient) GetTransition(ctx context.Context, transitionID string) (*pcs.Transition, error) {
+	transition, ok := f.transitions[transitionID]
+	if !ok {
+		return nil, &pcs.Error{Op: "getting power transition", StatusCode: 404}
+	}
+	return transition, nil
+}
+
+func TestDeterminePowerChanges(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.Spec.TenantResources = []TenantResource{
+		{Type: "compute", Xnames: []string{"x1", "x2", "x3"}, PowerPolicy: PowerPolicyForceOff},
+		{Type: "application", Xnames: []string{"x9"}},
+	}
+	tenant.Status.TenantResources = []TenantResource{
+		{Type: "compute", Xnames: []string{"x1", "x4"}, PowerPolicy: PowerPolicyForceOff},
+		{Type: "storage", Xnames: []string{"x5"}, PowerPolicy: PowerPolicyOff},
+	}
+
+	changes := determinePowerChanges(tenant)
+	expected := map[string][]string{
+		PowerPolicyOff:      {"x5"},
+		PowerPolicyForceOff: {"x4", "x2", "x3"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestEnsurePowerState(t *testing.T) {
+	fake := &fakePcsClient{
+		powerState:  map[string]string{"x1": "on", "x2": "off"},
+		transitions: map[string]*pcs.Transition{},
+	}
+	origClient := PcsClient
+	PcsClient = fake
+	t.Cleanup(func() { PcsClient = origClient })
+	tenant := &Tenant{}
+	tenant.Spec.TenantResources = []TenantResource{
+		{Type: "compute", Xnames: []string{"x1", "x2"}, PowerPolicy: PowerPolicyOff},
+	}
+
+	// Only xnames that are not already off are transitioned.
+	result, err := EnsurePowerState(context.Background(), logr.Discard(), tenant)
+	if err != nil || result.RequeueAfter != PowerPollInterval {
+		t.Fatalf("expected a requeue while the transition runs, got %v %v", result, err)
+	}
+	if len(fake.requests) != 1 || len(fake.requests[0].Location) != 1 || fake.requests[0].Location[0].Xname != "x1" {
+		t.Fatalf("unexpected transition requests %+v", fake.requests)
+	}
+	if !reflect.DeepEqual(PendingPowerTransitions(tenant), []string{"off-transition"}) {
+		t.Fatalf("unexpected pending transitions %v", PendingPowerTransitions(tenant))
+	}
+
+	// A transition that finishes with a failed task is reported as an error.
+	fake.transitions["off-transition"].TransitionStatus = pcs.TransitionStatusCompleted
+	fake.transitions["off-transition"].Tasks = []pcs.TransitionTask{{Xname: "x1", TaskStatus: pcs.TaskStatusFailed}}
+	_, err = EnsurePowerState(context.Background(), logr.Discard(), tenant)
+	if err == nil {
+		t.Fatal("expected an error for the failed transition")
+	}
+	if tenant.Status.PowerTransitions[0].Status != powerTransitionFailed {
+		t.Errorf("unexpected transition status %q", tenant.Status.PowerTransitions[0].Status)
+	}
+
+	// Once everything is off there is nothing more to do.
+	fake.powerState["x1"] = "off"
+	result, err = EnsurePowerState(context.Background(), logr.Discard(), tenant)
+	if err != nil || result.RequeueAfter != 0 || len(fake.requests) != 1 {
+		t.Errorf("expected no further transitions, got %v %v %+v", result, err, fake.requests)
+	}
+}
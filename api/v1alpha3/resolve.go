@@ -0,0 +1,247 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/xname"
+	"github.com/go-logr/logr"
+)
+
+// resourceRoles is the HSM role required of the nodes of each resource
+// type. Patterns and selectors only resolve to nodes with that role.
+var resourceRoles = map[string]string{
+	"compute":     "Compute",
+	"application": "Application",
+}
+
+// resolvesXnames returns true if the resource has xname patterns or a
+// selector to resolve against HSM.
+func (r TenantResource) resolvesXnames() bool {
+	return len(r.XnamePatterns) > 0 || r.Selector != nil
+}
+
+// ResourceXnames returns the xnames of a tenant resource: those listed
+// in Xnames, plus those its patterns and selector last resolved to.
+func (t *Tenant) ResourceXnames(resource TenantResource) []string {
+	if !resource.resolvesXnames() {
+		return resource.Xnames
+	}
+	xnames := append([]string(nil), resource.Xnames...)
+	for _, resolved := range t.Status.ResolvedXnames {
+		if resolved.Type == resource.Type {
+			xnames = appendUnique(xnames, resolved.Xnames)
+		}
+	}
+	return xnames
+}
+
+// ResolvedResources returns the tenant resources in the spec, each with
+// the xnames returned by ResourceXnames.
+func (t *Tenant) ResolvedResources() []TenantResource {
+	if t.Spec.TenantResources == nil {
+		return nil
+	}
+	resources := make([]TenantResource, 0, len(t.Spec.TenantResources))
+	for _, resource := range t.Spec.TenantResources {
+		resource.Xnames = t.ResourceXnames(resource)
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// ResolveXnames resolves the xname patterns and selectors of the tenant
+// resources against the HSM nodes, recording the xnames found in
+// Status.ResolvedXnames. It returns true if these changed.
+func ResolveXnames(ctx context.Context, log logr.Logger, t *Tenant) (bool, error) {
+	r := &xnameResolver{ctx: ctx, log: log}
+	var resolved []TenantResolvedXnames
+	for _, resource := range t.Spec.TenantResources {
+		if !resource.resolvesXnames() {
+			continue
+		}
+		xnames, err := r.resolve(resource)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve xnames for resource type %s: %w", resource.Type, err)
+		}
+		resolved = append(resolved, TenantResolvedXnames{Type: resource.Type, Xnames: xnames})
+	}
+
+	if reflect.DeepEqual(resolved, t.Status.ResolvedXnames) {
+		return false, nil
+	}
+	t.Status.ResolvedXnames = resolved
+	return true, nil
+}
+
+// xnameResolver resolves tenant resources against HSM, fetching the
+// node components and groups at most once.
+type xnameResolver struct {
+	ctx        context.Context
+	log        logr.Logger
+	components []hsm.Component
+	groups     map[string][]string
+}
+
+func (r *xnameResolver) nodes() ([]hsm.Component, error) {
+	if r.components == nil {
+		componentList, err := GetComponentList(r.ctx, r.log, "Node", "")
+		if err != nil {
+			return nil, err
+		}
+		r.components = append([]hsm.Component{}, componentList.Components...)
+	}
+	return r.components, nil
+}
+
+func (r *xnameResolver) groupMembers(label string) ([]string, error) {
+	if r.groups == nil {
+		_, groupList, err := ListHSMGroups(r.ctx, r.log)
+		if err != nil {
+			return nil, err
+		}
+		r.groups = map[string][]string{}
+		for _, group := range groupList {
+			r.groups[group.Label] = group.Members.Ids
+		}
+	}
+	members, ok := r.groups[label]
+	if !ok {
+		return nil, fmt.Errorf("HSM group %s not found", label)
+	}
+	return members, nil
+}
+
+// resolve returns the sorted xnames of the nodes matching one of the
+// resource's patterns, if any, and its selector, if set.
+func (r *xnameResolver) resolve(resource TenantResource) ([]string, error) {
+	patterns, err := parseXnamePatterns(resource.XnamePatterns)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var nids xname.Ranges
+	var members []string
+	if resource.Selector != nil {
+		nids, err = parseNIDs(resource.Selector.NIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(resource.Selector.HsmGroup) > 0 {
+			members, err = r.groupMembers(resource.Selector.HsmGroup)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	xnames := []string{}
+	for _, node := range nodes {
+		if role, ok := resourceRoles[resource.Type]; ok && !strings.EqualFold(node.Role, role) {
+			continue
+		}
+		if len(patterns) > 0 && !matchesAnyPattern(patterns, node.ID) {
+			continue
+		}
+		if s := resource.Selector; s != nil {
+			if (len(s.Role) > 0 && !strings.EqualFold(node.Role, s.Role)) ||
+				(len(s.SubRole) > 0 && !strings.EqualFold(node.SubRole, s.SubRole)) ||
+				(nids != nil && !nids.Contains(int(node.NID))) ||
+				(len(s.HsmGroup) > 0 && !Contains(members, node.ID)) {
+				continue
+			}
+		}
+		xnames = appendUnique(xnames, []string{node.ID})
+	}
+	sort.Strings(xnames)
+	return xnames, nil
+}
+
+func matchesAnyPattern(patterns []*xname.Pattern, id string) bool {
+	for _, pattern := range patterns {
+		if pattern.Match(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseXnamePatterns(patterns []string) ([]*xname.Pattern, error) {
+	parsed := make([]*xname.Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := xname.ParsePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+func parseNIDs(nids []string) (xname.Ranges, error) {
+	var ranges xname.Ranges
+	for _, nid := range nids {
+		r, err := xname.ParseRanges(nid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NIDs: %w", err)
+		}
+		ranges = append(ranges, r...)
+	}
+	return ranges, nil
+}
+
+// ValidateXnameSelectors checks the syntax of the xname patterns and
+// selectors of the tenant resources.
+func (t *Tenant) ValidateXnameSelectors() error {
+	for _, resource := range t.Spec.TenantResources {
+		if _, err := parseXnamePatterns(resource.XnamePatterns); err != nil {
+			return err
+		}
+		s := resource.Selector
+		if s == nil {
+			continue
+		}
+		if len(s.Role) == 0 && len(s.SubRole) == 0 && len(s.NIDs) == 0 && len(s.HsmGroup) == 0 {
+			return errors.New("the selector for resource type " + resource.Type + " must set at least one of role, subrole, nids or hsmgroup")
+		}
+		if _, err := parseNIDs(s.NIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolvedResources(t *testing.T) {
+	tenant := &Tenant{}
+	tenant.Spec.TenantResources = []TenantResource{
+		{Type: "compute", Xnames: []string{"x1"}, XnamePatterns: []string{"x1000c0s[0-1]b0n0"}},
+		{Type: "application", Xnames: []string{"x3"}},
+	}
+	tenant.Status.ResolvedXnames = []TenantResolvedXnames{{Type: "compute", Xnames: []string{"x1000c0s0b0n0", "x1"}}}
+
+	resources := tenant.ResolvedResources()
+	if !reflect.DeepEqual(resources[0].Xnames, []string{"x1", "x1000c0s0b0n0"}) || !reflect.DeepEqual(resources[1].Xnames, []string{"x3"}) {
+		t.Errorf("unexpected resolved resources %+v", resources)
+	}
+	if !reflect.DeepEqual(tenant.Spec.TenantResources[0].Xnames, []string{"x1"}) {
+		t.Errorf("expected the spec to be unchanged, got %+v", tenant.Spec.TenantResources[0])
+	}
+}
+
+func TestValidateXnameSelectors(t *testing.T) {
+	for _, tc := range []struct {
+		resource TenantResource
+		valid    bool
+	}{
+		{TenantResource{Type: "compute", XnamePatterns: []string{"x1000c[0-3]s[0-7]b[0-1]n[0-1]"}}, true},
+		{TenantResource{Type: "compute", Selector: &TenantXnameSelector{Role: "Compute", NIDs: []string{"1-128,256"}}}, true},
+		{TenantResource{Type: "compute", XnamePatterns: []string{"x1000c[0-3"}}, false},
+		{TenantResource{Type: "compute", Selector: &TenantXnameSelector{}}, false},
+		{TenantResource{Type: "compute", Selector: &TenantXnameSelector{NIDs: []string{"8-1"}}}, false},
+	} {
+		tenant := &Tenant{}
+		tenant.Spec.TenantResources = []TenantResource{tc.resource}
+		if err := tenant.ValidateXnameSelectors(); (err == nil) != tc.valid {
+			t.Errorf("%+v: expected valid %v, got %v", tc.resource, tc.valid, err)
+		}
+	}
+}
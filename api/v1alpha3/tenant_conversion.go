@@ -0,0 +1,97 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantConversionDataAnnotation holds the spec and status of a v1alpha3
+// Tenant, as JSON, on a Tenant converted to an older version that can't
+// represent all of it, so the fields the older version lacks survive a
+// round trip through it.
+const TenantConversionDataAnnotation = "tapms.hpe.com/conversion-data"
+
+// Hub marks v1alpha3 as the version Tenants in other versions are converted
+// through.
+func (*Tenant) Hub() {}
+
+//+kubebuilder:object:generate=false
+
+// tenantConversionData is the content of TenantConversionDataAnnotation.
+type tenantConversionData struct {
+	Spec   TenantSpec   `json:"spec"`
+	Status TenantStatus `json:"status"`
+}
+
+// MarshalConversionData stores the spec and status of src in an annotation
+// on dst, a Tenant converted from src.
+func MarshalConversionData(src *Tenant, dst metav1.Object) error {
+	data, err := json.Marshal(tenantConversionData{Spec: src.Spec, Status: src.Status})
+	if err != nil {
+		return err
+	}
+	annotations := dst.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[TenantConversionDataAnnotation] = string(data)
+	dst.SetAnnotations(annotations)
+	return nil
+}
+
+// UnmarshalConversionData restores the spec and status stored on src by
+// MarshalConversionData into dst, returning false if src has none.
+func UnmarshalConversionData(src metav1.Object, dst *Tenant) (bool, error) {
+	data, ok := src.GetAnnotations()[TenantConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+	var restored tenantConversionData
+	if err := json.Unmarshal([]byte(data), &restored); err != nil {
+		return false, err
+	}
+	dst.Spec = restored.Spec
+	dst.Status = restored.Status
+	return true, nil
+}
+
+// RemoveConversionData removes the annotation added by
+// MarshalConversionData from obj.
+func RemoveConversionData(obj metav1.Object) {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[TenantConversionDataAnnotation]; !ok {
+		return
+	}
+	delete(annotations, TenantConversionDataAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+}
@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2025 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -45,25 +45,105 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// @Description HSM attributes selecting the nodes for a tenant resource. A node must match every attribute set.
+type TenantXnameSelector struct {
+	// The HSM role of the nodes.
+	Role string `json:"role,omitempty" example:"Compute"`
+	// The HSM subrole of the nodes.
+	SubRole string `json:"subrole,omitempty" example:"UAN"`
+	// NIDs and NID ranges.
+	NIDs []string `json:"nids,omitempty" example:"1-128,256"`
+	// The label of an existing HSM group the nodes are members of.
+	HsmGroup string `json:"hsmgroup,omitempty" example:"rack-x1000"`
+} // @name TenantXnameSelector
+
 // @Description The desired resources for the Tenant
 type TenantResource struct {
-	Type                      string   `json:"type" example:"compute" binding:"required"`
-	Xnames                    []string `json:"xnames" example:"x0c3s5b0n0,x0c3s6b0n0" binding:"required"`
-	HsmPartitionName          string   `json:"hsmpartitionname,omitempty" example:"blue"`
-	HsmGroupLabel             string   `json:"hsmgrouplabel,omitempty" example:"green"`
-	EnforceExclusiveHsmGroups bool     `json:"enforceexclusivehsmgroups"`
+	Type string `json:"type" example:"compute" binding:"required"`
+	//+kubebuilder:validation:Optional
+	Xnames []string `json:"xnames" example:"x0c3s5b0n0,x0c3s6b0n0"`
+	//+kubebuilder:validation:Optional
+	// Xname patterns with bracketed numeric ranges, e.g.
+	// x1000c[0-3]s[0-7]b[0-1]n[0-1]. Each matches the HSM nodes with the role
+	// of the resource type whose xname fits it.
+	XnamePatterns []string `json:"xnamepatterns,omitempty" example:"x1000c[0-3]s[0-7]b[0-1]n[0-1]"`
+	//+kubebuilder:validation:Optional
+	// Select HSM nodes by their attributes. With patterns as well, nodes must
+	// match both a pattern and the selector. The nodes selected, plus any
+	// listed in Xnames, are recorded in Status.ResolvedXnames.
+	Selector                  *TenantXnameSelector `json:"selector,omitempty"`
+	HsmPartitionName          string               `json:"hsmpartitionname,omitempty" example:"blue"`
+	HsmGroupLabel             string               `json:"hsmgrouplabel,omitempty" example:"green"`
+	EnforceExclusiveHsmGroups bool                 `json:"enforceexclusivehsmgroups"`
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=off;force-off
+	// Power off xnames added to or removed from this resource, so they stop
+	// running the previous tenant's workload. Leave empty to not manage power.
+	PowerPolicy string `json:"powerpolicy,omitempty" example:"off,force-off"`
 } // @name TenantResource
 
+// @Description A power transition requested from PCS for xnames changing tenants
+type TenantPowerTransition struct {
+	// The PCS transition ID.
+	TransitionID string `json:"transitionid" example:"3d8e7f32-5c4a-4b3a-9a4e-2f1c0d6b7e11"`
+	// The PCS operation, off or force-off.
+	Operation string   `json:"operation" example:"off"`
+	Xnames    []string `json:"xnames,omitempty" example:"x0c3s5b0n0,x0c3s6b0n0"`
+	// The PCS transition status, plus failed if any xname failed to power off.
+	Status       string   `json:"status" example:"new,in-progress,completed,aborted,failed"`
+	FailedXnames []string `json:"failedxnames,omitempty" example:"x0c3s6b0n0"`
+	// The generation of the tenant spec that triggered the transition.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+} // @name TenantPowerTransition
+
+// @Description A backend resource created by a provisioning step
+type TenantJournalEntry struct {
+	// The provisioning step that created the resource.
+	Step string `json:"step" example:"HsmPartitionReady"`
+	// The kind of resource: Namespace, HsmPartition, HsmGroup, KeycloakGroup or VaultTransit.
+	Kind string `json:"kind" example:"HsmPartition"`
+	Name string `json:"name" example:"blue"`
+	// The generation of the tenant spec the resource was created for.
+	Generation int64       `json:"generation,omitempty"`
+	Time       metav1.Time `json:"time,omitempty" swaggertype:"string" format:"date-time"`
+} // @name TenantJournalEntry
+
 // @Description The webhook definition to call an API for tenant CRUD operations
 type TenantHook struct {
 	Name string `json:"name,omitempty"`
 	//+kubebuilder:default:=false
 	//+kubebuilder:validation:Optional
-	BlockingCall bool     `json:"blockingcall"`
-	Url          string   `json:"url,omitempty" example:"http://<url>:<port>"`
-	EventTypes   []string `json:"eventtypes,omitempty" example:"CREATE, UPDATE, DELETE"`
+	BlockingCall bool   `json:"blockingcall"`
+	Url          string `json:"url,omitempty" example:"http://<url>:<port>"`
+	// CREATE, UPDATE and DELETE are sent on admission, and the lifecycle events
+	// PROVISIONED, DEPROVISIONED and FAILED once the tenant controller is done.
+	EventTypes []string `json:"eventtypes,omitempty" example:"CREATE, UPDATE, DELETE, PROVISIONED, DEPROVISIONED, FAILED"`
+	//+kubebuilder:validation:Optional
+	// The name of a Secret, in the namespace of the tenant or global hook, with
+	// credentials for calling the hook: hmac-key to sign payloads, token for a
+	// bearer token, tls.crt and tls.key for a client certificate, and ca.crt to
+	// verify the hook's server certificate.
+	SecretName string `json:"secretname,omitempty" example:"vcluster-blue-hook"`
 } // @name TenantHook
 
+// @Description The desired state of a global tenant hook: the hook, and filters on the tenants and changes it is called for
+type GlobalTenantHookSpec struct {
+	TenantHook `json:",inline"`
+	//+kubebuilder:validation:Optional
+	// Only call the hook for tenants whose labels match the selector. An
+	// empty selector matches every tenant.
+	TenantSelector *metav1.LabelSelector `json:"tenantselector,omitempty" swaggertype:"object"`
+	//+kubebuilder:validation:Optional
+	// Only call the hook for events changing a tenant resource of one of these
+	// types. For UPDATE events that is a resource whose xnames or settings
+	// changed, for other events any resource the tenant has.
+	ResourceTypes []string `json:"resourcetypes,omitempty" example:"application"`
+	//+kubebuilder:validation:Optional
+	// Only call the hook for UPDATE events changing one of these tenant spec
+	// fields. Other events are not filtered on changed fields.
+	ChangedFields []string `json:"changedfields,omitempty" example:"childnamespaces,tenantresources,tenantkms,tenanthooks,driftpolicy"`
+} // @name GlobalTenantHookSpec
+
 // @Description The Vault KMS transit engine specification for the tenant
 type TenantKmsResource struct {
 	//+kubebuilder:default:=false
@@ -97,6 +177,7 @@ type TenantKmsStatus struct {
 type TenantSpec struct {
 	TenantName string `json:"tenantname" example:"vcluster-blue" binding:"required"`
 	//+kubebuilder:validation:Optional
+	// Deprecated: use Status.Phase, which is owned by the tenant controller.
 	State           string   `json:"state" example:"New,Deploying,Deployed,Deleting"`
 	ChildNamespaces []string `json:"childnamespaces" example:"vcluster-blue-slurm"`
 	// The desired resources for the Tenant
@@ -105,22 +186,93 @@ type TenantSpec struct {
 	TenantKmsResource TenantKmsResource `json:"tenantkms"`
 	//+kubebuilder:validation:Optional
 	TenantHooks []TenantHook `json:"tenanthooks"`
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=report;enforce
+	//+kubebuilder:default:=report
+	// Whether changes made directly to the tenant's HSM partitions and groups
+	// are only reported, or are also reverted to match the spec.
+	DriftPolicy string `json:"driftpolicy,omitempty" example:"report,enforce"`
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=retry;rollback
+	//+kubebuilder:default:=retry
+	// Whether a tenant that fails to provision for the first time is retried,
+	// or the backend resources already created for it are deleted again.
+	// Transient backend failures are always retried.
+	FailurePolicy string `json:"failurepolicy,omitempty" example:"retry,rollback"`
 } //@name TenantSpec
 
+// @Description The xnames a tenant resource's patterns and selector resolved to
+type TenantResolvedXnames struct {
+	// The resource type.
+	Type   string   `json:"type" example:"compute"`
+	Xnames []string `json:"xnames,omitempty" example:"x1000c0s0b0n0,x1000c0s0b0n1"`
+} // @name TenantResolvedXnames
+
+// @Description The members of an HSM partition or group, as last applied by TAPMS
+type TenantHsmMembers struct {
+	// The HSM partition name or group label.
+	Name   string   `json:"name" example:"blue"`
+	Xnames []string `json:"xnames,omitempty" example:"x0c3s5b0n0,x0c3s6b0n0"`
+} // @name TenantHsmMembers
+
+// @Description What TAPMS last applied to each backend for the tenant, updated as each step completes
+type TenantAppliedStatus struct {
+	// The child namespaces created for the tenant.
+	ChildNamespaces []string `json:"childnamespaces,omitempty" example:"vcluster-blue-slurm"`
+	//+listType=map
+	//+listMapKey=name
+	HsmPartitions []TenantHsmMembers `json:"hsmpartitions,omitempty"`
+	//+listType=map
+	//+listMapKey=name
+	HsmGroups []TenantHsmMembers `json:"hsmgroups,omitempty"`
+} // @name TenantAppliedStatus
+
 // @Description The observed state of Tenant
 type TenantStatus struct {
+	// The child namespaces, as of the last time the tenant was deployed.
 	ChildNamespaces []string `json:"childnamespaces,omitempty" example:"vcluster-blue-slurm"`
-	// The desired resources for the Tenant
+	// The resources for the Tenant, as of the last time it was deployed.
 	TenantResources []TenantResource `json:"tenantresources,omitempty"`
 	UUID            string           `json:"uuid,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" format:"uuid"`
 	TenantKmsStatus TenantKmsStatus  `json:"tenantkms,omitempty"`
 	TenantHooks     []TenantHook     `json:"tenanthooks,omitempty"`
+	// The lifecycle phase of the tenant, as last recorded by the tenant controller.
+	Phase string `json:"phase,omitempty" example:"New,Deploying,Deployed,Deleting,Failed"`
+	// The most recent generation of the tenant spec acted on by the tenant controller.
+	ObservedGeneration int64 `json:"observedgeneration,omitempty"`
+	// The generation of the tenant spec a PROVISIONED lifecycle event was
+	// last queued for.
+	ProvisionedGeneration int64 `json:"provisionedgeneration,omitempty"`
+	// The generation of the tenant spec a FAILED lifecycle event was last
+	// queued for.
+	FailedGeneration int64 `json:"failedgeneration,omitempty"`
+	// The outcome of each provisioning step (NamespacesReady, HsmPartitionReady, etc).
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" swaggertype:"array,object"`
+	// The most recent power transitions requested for xnames changing tenants.
+	PowerTransitions []TenantPowerTransition `json:"powertransitions,omitempty"`
+	// The backend resources created while provisioning the tenant, oldest
+	// first. Cleared once the tenant is deployed.
+	Journal []TenantJournalEntry `json:"journal,omitempty"`
+	// The namespaces and HSM members applied so far, which may be ahead of
+	// ChildNamespaces and TenantResources while the tenant is deploying.
+	Applied *TenantAppliedStatus `json:"applied,omitempty"`
+	// The xnames of each resource with xname patterns or a selector, as last
+	// resolved against HSM.
+	//+listType=map
+	//+listMapKey=type
+	ResolvedXnames []TenantResolvedXnames `json:"resolvedxnames,omitempty"`
 } // @name TenantStatus
 
 //+k8s:openapi-gen=true
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // @Description The primary schema/definition of a tenant
 type Tenant struct {
@@ -151,7 +303,7 @@ type GlobalTenantHook struct {
 	metav1.TypeMeta   `json:",inline" swaggerignore:"true"`
 	metav1.ObjectMeta `json:"metadata,omitempty" swaggerignore:"true"`
 	// The desired state of the global tenant hook
-	Spec TenantHook `json:"spec,omitempty" binding:"required"`
+	Spec GlobalTenantHookSpec `json:"spec,omitempty" binding:"required"`
 } // @name Tenant
 
 //+kubebuilder:object:root=true
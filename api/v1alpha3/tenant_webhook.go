@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -30,16 +30,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	admissionv1 "k8s.io/api/admission/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var Log = logf.Log.WithName("tenants")
 
+// The path of the Tenant validating webhook.
+const tenantValidatePath = "/validate-tapms-hpe-com-v1alpha3-tenant"
+
+// SetupWebhookWithManager registers the Tenant defaulting, validating and
+// conversion webhooks.
 func (t *Tenant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(tenantValidatePath, &webhook.Admission{Handler: &tenantValidator{}})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(t).
 		Complete()
@@ -67,68 +75,136 @@ func (t *Tenant) Default() {
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
-//+kubebuilder:webhook:path=/validate-tapms-hpe-com-v1alpha3-tenant,mutating=false,failurePolicy=fail,sideEffects=None,groups=tapms.hpe.com,resources=tenants,verbs=create;update;delete,versions=v1alpha3,name=vtenant.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-tapms-hpe-com-v1alpha3-tenant,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=tapms.hpe.com,resources=tenants,verbs=create;update;delete,versions=v1alpha3,name=vtenant.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &Tenant{}
+// tenantValidator validates Tenants. It handles the admission request
+// itself, rather than Tenant implementing webhook.Validator, as the tenant
+// hooks are not called or queued for a dry run.
+type tenantValidator struct {
+	decoder *admission.Decoder
+}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (t *Tenant) ValidateCreate() error {
-	Log.Info("Validating create for", "tenant", t.Name)
+var _ admission.DecoderInjector = &tenantValidator{}
 
-	for _, specResource := range t.Spec.TenantResources {
-		if specResource.Type == "compute" {
-			err := t.ValidateNodeTypeForXnames(specResource.Xnames, "Node", "Compute")
-			if err != nil {
-				return err
-			}
+// InjectDecoder implements admission.DecoderInjector.
+func (v *tenantValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
 
+// Handle validates created, updated and deleted Tenants.
+func (v *tenantValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	dryRun := req.DryRun != nil && *req.DryRun
+	t := &Tenant{}
+	var err error
+	switch req.Operation {
+	case admissionv1.Create:
+		if err := v.decoder.Decode(req, t); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
 		}
-		if specResource.Type == "application" {
-			err := t.ValidateNodeTypeForXnames(specResource.Xnames, "Node", "Application")
-			if err != nil {
-				return err
-			}
-
+		err = t.validateCreate(dryRun)
+	case admissionv1.Update:
+		old := &Tenant{}
+		if err := v.decoder.Decode(req, t); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
 		}
-		err := t.ValidateExclusiveGroupMembership(specResource.Xnames, specResource.HsmGroupLabel, specResource.EnforceExclusiveHsmGroups)
-		if err != nil {
-			return err
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		err = t.validateUpdate(old, dryRun)
+	case admissionv1.Delete:
+		// The object being deleted is in OldObject.
+		if err := v.decoder.DecodeRaw(req.OldObject, t); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
 		}
+		err = t.validateDelete(dryRun)
 	}
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (t *Tenant) validateCreate(dryRun bool) error {
+	Log.Info("Validating create for", "tenant", t.Name)
 
-	err := CallHooks(t, Log, "CREATE")
+	err := t.ValidateSpec()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return t.callHooks(nil, "CREATE", dryRun)
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (t *Tenant) ValidateUpdate(old runtime.Object) error {
+func (t *Tenant) validateUpdate(old *Tenant, dryRun bool) error {
 	Log.Info("Validating update for", "tenant", t.Name)
 
-	for _, specResource := range t.Spec.TenantResources {
+	err := t.ValidateSpecUpdate()
+	if err != nil {
+		return err
+	}
 
-		if specResource.Type == "compute" {
-			err := t.ValidateNodeTypeForXnames(specResource.Xnames, "Node", "Compute")
-			if err != nil {
-				return err
-			}
+	return t.callHooks(old, "UPDATE", dryRun)
+}
 
-		}
-		if specResource.Type == "application" {
-			err := t.ValidateNodeTypeForXnames(specResource.Xnames, "Node", "Application")
+// callHooks calls the hooks for an admission event, unless the request is
+// a dry run, since calling a hook or queuing a delivery is a side effect.
+func (t *Tenant) callHooks(old *Tenant, event string, dryRun bool) error {
+	if dryRun {
+		Log.Info("Not calling hooks for dry run", "tenant", t.Name, "event", event)
+		return nil
+	}
+	return CallHooks(t, old, Log, event)
+}
+
+// ValidateSpec checks the tenant resources against HSM and the xnames
+// claimed by other tenants. Xname patterns and selectors are resolved
+// against HSM first, and the xnames found are checked too. It is shared by
+// the admission webhook and the tenant API.
+func (t *Tenant) ValidateSpec() error {
+	err := t.ValidateXnameSelectors()
+	if err != nil {
+		return err
+	}
+
+	resolved := t.DeepCopy()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = ResolveXnames(ctx, Log, resolved)
+	if err != nil {
+		return err
+	}
+
+	err = resolved.ValidateXnameClaims()
+	if err != nil {
+		return err
+	}
+
+	for _, specResource := range resolved.ResolvedResources() {
+		if role, ok := resourceRoles[specResource.Type]; ok {
+			err := t.ValidateNodeTypeForXnames(specResource.Xnames, "Node", role)
 			if err != nil {
 				return err
 			}
 		}
-
 		err := t.ValidateExclusiveGroupMembership(specResource.Xnames, specResource.HsmGroupLabel, specResource.EnforceExclusiveHsmGroups)
 		if err != nil {
 			return err
 		}
+	}
 
+	return nil
+}
+
+// ValidateSpecUpdate runs ValidateSpec, and also checks that immutable
+// fields have not changed from the last applied tenant resources.
+func (t *Tenant) ValidateSpecUpdate() error {
+	err := t.ValidateSpec()
+	if err != nil {
+		return err
+	}
+
+	for _, specResource := range t.Spec.TenantResources {
 		for _, statusResource := range t.Status.TenantResources {
 			if statusResource.Type == specResource.Type {
 				//
@@ -147,22 +223,12 @@ func (t *Tenant) ValidateUpdate(old runtime.Object) error {
 		}
 	}
 
-	err := CallHooks(t, Log, "UPDATE")
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (t *Tenant) ValidateDelete() error {
+func (t *Tenant) validateDelete(dryRun bool) error {
 	Log.Info("Validating delete for", "tenant", t.Name)
-	err := CallHooks(t, Log, "DELETE")
-	if err != nil {
-		return err
-	}
-	return nil
+	return t.callHooks(nil, "DELETE", dryRun)
 }
 
 func (t *Tenant) ValidateNodeTypeForXnames(xnames []string, nodeType string, role string) error {
@@ -211,7 +277,7 @@ func (t *Tenant) ValidateExclusiveGroupMembership(xnames []string, hsmGroupLabel
 	currentTenantXnames = make([]string, 0, len(xnames))
 	for _, xname := range xnames {
 		for _, group := range groupList {
-			if (group.ExclusiveGroup != "tapms-exclusive-group-label") || (group.Label == hsmGroupLabel) {
+			if (group.ExclusiveGroup != hsmExclusiveGroupLabel) || (group.Label == hsmGroupLabel) {
 				continue
 			}
 
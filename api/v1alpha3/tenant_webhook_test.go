@@ -0,0 +1,93 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestTenantValidatorDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	HooksClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	defer func() { HooksClient = nil }()
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := &tenantValidator{decoder: decoder}
+
+	tenant := &Tenant{
+		TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "Tenant"},
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"},
+		Spec: TenantSpec{
+			TenantName:  "vcluster-blue",
+			TenantHooks: []TenantHook{{Name: "notify", Url: "http://notify", EventTypes: []string{"CREATE", "DELETE"}}},
+		},
+	}
+	raw, err := json.Marshal(tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveries := func() int {
+		list := &HookDeliveryList{}
+		if err := HooksClient.List(context.Background(), list); err != nil {
+			t.Fatal(err)
+		}
+		return len(list.Items)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		dryRun := dryRun
+		create := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &dryRun}}
+		create.Object.Raw = raw
+		remove := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Delete, DryRun: &dryRun}}
+		remove.OldObject.Raw = raw
+		for _, req := range []admission.Request{create, remove} {
+			if resp := validator.Handle(context.Background(), req); !resp.Allowed {
+				t.Fatalf("expected the tenant to be allowed, got %v", resp.Result)
+			}
+		}
+		expected := 2
+		if dryRun {
+			expected = 0
+		}
+		if n := deliveries(); n != expected {
+			t.Errorf("dry run %v: expected %d deliveries, got %d", dryRun, expected, n)
+		}
+	}
+}
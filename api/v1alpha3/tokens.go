@@ -0,0 +1,220 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/keycloak"
+)
+
+// masterAdminSecret and adminClientSecret return the secrets holding the
+// credentials used to get Keycloak tokens.
+func masterAdminSecret() types.NamespacedName {
+	return currentConfig().Keycloak.MasterAdminSecret.NamespacedName()
+}
+
+func adminClientSecret() types.NamespacedName {
+	return currentConfig().Keycloak.AdminClientSecret.NamespacedName()
+}
+
+// Tokens for the master realm, used to manage Keycloak groups, and for
+// the cluster realm, used for requests through the API gateway. They are
+// set by Configure.
+var (
+	masterTokens  *keycloak.TokenCache
+	clusterTokens *keycloak.TokenCache
+)
+
+func newTokenCache(base string, realm string, httpClient *http.Client, credentials keycloak.Credentials) *keycloak.TokenCache {
+	return keycloak.NewTokenCache(
+		fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", base, realm),
+		httpClient, credentials)
+}
+
+// KeycloakSecretsReader reads the Keycloak credential secrets until
+// KeycloakSecretWatcher has synced. If nil, a client is created from the
+// kubeconfig.
+var KeycloakSecretsReader client.Reader
+
+// tokensFor returns the token cache using the credentials in secret.
+func tokensFor(secret types.NamespacedName) *keycloak.TokenCache {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if secret == operatorConfig.Keycloak.MasterAdminSecret.NamespacedName() {
+		return masterTokens
+	}
+	return clusterTokens
+}
+
+// keycloakSecrets holds the credential secrets once KeycloakSecretWatcher
+// has synced. Before that, and for secrets named in a reloaded config
+// file, secrets are read directly from the API server.
+var keycloakSecrets = &secretCache{secrets: map[types.NamespacedName]*corev1.Secret{}}
+
+type secretCache struct {
+	mu      sync.RWMutex
+	watched map[types.NamespacedName]bool
+	secrets map[types.NamespacedName]*corev1.Secret
+	reader  client.Reader
+}
+
+func (c *secretCache) get(ctx context.Context, name types.NamespacedName) (*corev1.Secret, error) {
+	c.mu.RLock()
+	if c.watched[name] {
+		defer c.mu.RUnlock()
+		secret, ok := c.secrets[name]
+		if !ok {
+			return nil, k8serrors.NewNotFound(corev1.Resource("secrets"), name.Name)
+		}
+		return secret, nil
+	}
+	c.mu.RUnlock()
+
+	reader, err := c.directReader()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	err = reader.Get(ctx, name, secret)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (c *secretCache) directReader() (client.Reader, error) {
+	if KeycloakSecretsReader != nil {
+		return KeycloakSecretsReader, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.reader == nil {
+		cfg, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		reader, err := client.New(cfg, client.Options{})
+		if err != nil {
+			return nil, err
+		}
+		c.reader = reader
+	}
+	return c.reader, nil
+}
+
+// set records a changed (or, if secret is nil, deleted) secret and drops
+// the tokens obtained with its previous contents.
+func (c *secretCache) set(name types.NamespacedName, secret *corev1.Secret) {
+	c.mu.Lock()
+	prev, ok := c.secrets[name]
+	if secret == nil {
+		delete(c.secrets, name)
+	} else {
+		c.secrets[name] = secret
+	}
+	c.mu.Unlock()
+
+	if ok && (secret == nil || !reflect.DeepEqual(prev.Data, secret.Data)) {
+		Log.Info("Keycloak credentials changed in secret " + name.String())
+		tokensFor(name).Invalidate()
+	}
+}
+
+func (c *secretCache) setSynced(names []types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watched = map[types.NamespacedName]bool{}
+	for _, name := range names {
+		c.watched[name] = true
+	}
+}
+
+//+kubebuilder:object:generate=false
+
+// KeycloakSecretWatcher watches the Keycloak credential secrets, so they
+// are not read from the API server for every token request, and tokens
+// are renewed when the credentials change. It is run by the manager.
+type KeycloakSecretWatcher struct {
+	Config *rest.Config
+}
+
+// NeedLeaderElection returns false, since the webhook and tenant API
+// need tokens on every replica.
+func (w *KeycloakSecretWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs an informer for each credential secret until ctx is done.
+func (w *KeycloakSecretWatcher) Start(ctx context.Context) error {
+	clientset, err := kubernetes.NewForConfig(w.Config)
+	if err != nil {
+		return err
+	}
+
+	var synced []cache.InformerSynced
+	names := []types.NamespacedName{masterAdminSecret(), adminClientSecret()}
+	for _, name := range names {
+		name := name
+		lw := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "secrets", name.Namespace,
+			fields.OneTermEqualSelector("metadata.name", name.Name))
+		_, informer := cache.NewInformer(lw, &corev1.Secret{}, 0, cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				keycloakSecrets.set(name, obj.(*corev1.Secret))
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				keycloakSecrets.set(name, obj.(*corev1.Secret))
+			},
+			DeleteFunc: func(interface{}) {
+				keycloakSecrets.set(name, nil)
+			},
+		})
+		go informer.Run(ctx.Done())
+		synced = append(synced, informer.HasSynced)
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return fmt.Errorf("failed to sync Keycloak secrets")
+	}
+	keycloakSecrets.setSynced(names)
+	<-ctx.Done()
+	return nil
+}
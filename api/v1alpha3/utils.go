@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2024 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -34,7 +34,9 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/operatorconfig"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,10 +46,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
-var (
-	apiGateway = getEnvVal("API_GATEWAY", "api-gw-service-nmn.local")
-	serverPort = getEnvVal("SERVER_PORT", "80")
-)
+var driftCheckInterval = getEnvVal("DRIFT_CHECK_INTERVAL", "10m")
 
 func NewHttpClient() *http.Client {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
@@ -59,12 +58,34 @@ func NewHttpClient() *http.Client {
 	return httpClient
 }
 
+// newServiceHttpClient returns a client trusting the service's CA bundle
+// and limited to its timeout.
+func newServiceHttpClient(service operatorconfig.Service) (*http.Client, error) {
+	tlsConfig, err := service.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: service.Timeout.Duration}, nil
+}
+
 func GetApiGateway() string {
-	return apiGateway
+	return currentConfig().ApiGateway
 }
 
 func GetServerPort() string {
-	return ":" + serverPort
+	return ":" + currentConfig().ServerPort
+}
+
+// GetDriftCheckInterval returns how often deployed tenants are compared
+// against HSM, defaulting to 10 minutes if DRIFT_CHECK_INTERVAL is invalid.
+func GetDriftCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(driftCheckInterval)
+	if err != nil || interval <= 0 {
+		return 10 * time.Minute
+	}
+	return interval
 }
 
 func Difference(a, b []string) (diff []string) {
@@ -139,7 +160,7 @@ func TenantIsUpdated(tenant *Tenant) bool {
 		isUpdated = true
 	}
 
-	if !reflect.DeepEqual(tenant.Status.TenantResources, tenant.Spec.TenantResources) {
+	if !reflect.DeepEqual(tenant.Status.TenantResources, tenant.ResolvedResources()) {
 		isUpdated = true
 	}
 
@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2023-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -40,19 +40,23 @@ import (
 	auth "github.com/hashicorp/vault/api/auth/kubernetes"
 )
 
-// The definitions below may need to be configurable by the site. For now,
-// they are tracked below.
-
-// The predefined TAPMS Vault role. This defines Vault actions that the client, based on
-// the K8s service account, will be allowed to perform.
-var tapms_vault_role = "tapms-operator"
-
-// The K8s service account token used to perform Vault authentication.
-var k8s_service_account_token_path = "/var/run/secrets/kubernetes.io/serviceaccount/token"
-
 // The tenant Vault transit engine name prefix.
 var tapms_transit_prefix = "cray-tenant-"
 
+// The tenant Vault transit engine name, either as previously recorded in
+// the status or from the tenant UUID. It will be of the form cray-tenant-$uuid.
+// Returns an empty string if a new UUID needs to be generated for the name.
+func transitEngineName(t *Tenant) string {
+	if t.Status.TenantKmsStatus.TransitName != "" {
+		return t.Status.TenantKmsStatus.TransitName
+	}
+	if t.Status.UUID == "" {
+		return ""
+	}
+	// Include the tenant UUID in the new transit engine name.
+	return fmt.Sprintf("%s%s", tapms_transit_prefix, t.Status.UUID)
+}
+
 // Create the tenant Vault transit engine
 func CreateVaultTransit(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.Result, error) {
 	fmt.Println("CreateVaultTransit called")
@@ -75,18 +79,10 @@ func CreateVaultTransit(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 		transit_engine_key_name := t.Spec.TenantKmsResource.KeyName
 		transit_engine_key_type := t.Spec.TenantKmsResource.KeyType
 
-		// Check if a tenant transit name was previously reocrded in the status.
-		// It will be of the form cray-tenant-$uuid.
-		engine_name := t.Status.TenantKmsStatus.TransitName
+		engine_name := transitEngineName(t)
 		if engine_name == "" {
-			// If not found in the status, generate it now.
-			if t.Status.UUID == "" {
-				// If we have no tenant UUID, generate a new UUID for the transit engine name.
-				engine_name = fmt.Sprintf("%s%s", tapms_transit_prefix, uuid.New().String())
-			} else {
-				// Include the tenant UUID in the new transit engine name.
-				engine_name = fmt.Sprintf("%s%s", tapms_transit_prefix, t.Status.UUID)
-			}
+			// If we have no tenant UUID, generate a new UUID for the transit engine name.
+			engine_name = fmt.Sprintf("%s%s", tapms_transit_prefix, uuid.New().String())
 		}
 
 		// Check for the transit engine. Create if it does not exist.
@@ -158,6 +154,7 @@ func CreateVaultTransit(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 				// Record the transit engine name.
 				// The tenant controller will update the status with this info.
 				t.Status.TenantKmsStatus.TransitName = engine_name
+				t.RecordCreated(ConditionVaultKmsReady, JournalKindVaultTransit, engine_name)
 			} else {
 				// We had some other type of error.
 				return ctrl.Result{}, err
@@ -300,16 +297,38 @@ func DeleteVaultTransit(ctx context.Context, log logr.Logger, t *Tenant) (ctrl.R
 func GetVaultClient(log logr.Logger) (client *vault.Client, err error) {
 	// See https://github.com/hashicorp/vault-examples/blob/main/examples/auth-methods/kubernetes/go/example.go
 
+	vaultConfig := currentConfig().Vault
 	config := vault.DefaultConfig() // modify for more granular configuration
+	if vaultConfig.URL != "" {
+		config.Address = vaultConfig.URL
+	}
+	if vaultConfig.Timeout.Duration > 0 {
+		config.Timeout = vaultConfig.Timeout.Duration
+	}
+	// Without a CA bundle or insecureSkipVerify, VAULT_CACERT and
+	// VAULT_SKIP_VERIFY still apply.
+	if vaultConfig.CABundle != "" || vaultConfig.InsecureSkipVerify {
+		err = config.ConfigureTLS(&vault.TLSConfig{CACert: vaultConfig.CABundle, Insecure: vaultConfig.InsecureSkipVerify})
+		if err != nil {
+			return nil, fmt.Errorf("unable to configure Vault TLS: %w", err)
+		}
+	}
+	config.HttpClient = newVaultHttpClient(config.HttpClient)
 
 	client, err = vault.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize Vault client: %w", err)
 	}
 
+	// A token from VAULT_TOKEN, e.g. the root token of a dev mode Vault,
+	// is used instead of Kubernetes auth.
+	if client.Token() != "" {
+		return client, nil
+	}
+
 	k8sAuth, err := auth.NewKubernetesAuth(
-		tapms_vault_role,
-		auth.WithServiceAccountTokenPath(k8s_service_account_token_path),
+		vaultConfig.Role,
+		auth.WithServiceAccountTokenPath(vaultConfig.ServiceAccountTokenPath),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize Kubernetes auth method: %w", err)
@@ -0,0 +1,137 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TenantXnameField is the field index from an xname to the tenants whose
+// resources claim it.
+const TenantXnameField = "spec.tenantresources.xnames"
+
+// TenantIndexReader is used by admission validation to find the tenants
+// claiming an xname. It is set to the manager's cached client, which has
+// the TenantXnameField index. Xname claims are not checked if it is nil.
+var TenantIndexReader client.Reader
+
+// IndexTenantXnames adds the TenantXnameField index to the manager's
+// cache. It must be called before the manager is started.
+func IndexTenantXnames(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &Tenant{}, TenantXnameField, func(obj client.Object) []string {
+		return obj.(*Tenant).ClaimedXnames()
+	})
+}
+
+// ClaimedXnames returns the xnames of all the tenant's resources.
+func (t *Tenant) ClaimedXnames() []string {
+	var xnames []string
+	for _, resource := range t.ResolvedResources() {
+		xnames = appendUnique(xnames, resource.Xnames)
+	}
+	return xnames
+}
+
+// ListTenantsByXname returns the tenants claiming xname, using the
+// TenantXnameField index. The tenants found are checked against their
+// spec, so a reader without the index returns the same tenants.
+func ListTenantsByXname(ctx context.Context, reader client.Reader, xname string, opts ...client.ListOption) ([]Tenant, error) {
+	var tenantList TenantList
+	err := reader.List(ctx, &tenantList, append(opts, client.MatchingFields{TenantXnameField: xname})...)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	for _, tenant := range tenantList.Items {
+		if Contains(tenant.ClaimedXnames(), xname) {
+			tenants = append(tenants, tenant)
+		}
+	}
+	return tenants, nil
+}
+
+// ValidateXnameClaims returns an error if any of the tenant's xnames is
+// claimed by another tenant, whether or not its HSM groups are exclusive.
+func (t *Tenant) ValidateXnameClaims() error {
+	if TenantIndexReader == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var conflicts []string
+	for _, xname := range t.ClaimedXnames() {
+		tenants, err := ListTenantsByXname(ctx, TenantIndexReader, xname)
+		if err != nil {
+			return err
+		}
+		for _, other := range tenants {
+			if other.Namespace != t.Namespace || other.Name != t.Name {
+				conflicts = append(conflicts, fmt.Sprintf("%s (tenant %s)", xname, other.Spec.TenantName))
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("the following xname(s) are already claimed by another tenant: %v", conflicts)
+	}
+	return nil
+}
+
+// DropClaimedResolvedXnames removes the resolved xnames claimed by another
+// tenant from the tenant's status, so they are not applied, and returns
+// them. Admission only checks the xnames resolved at the time, so a node
+// added to HSM later may match more than one tenant's patterns or
+// selectors; the tenant that claimed it first keeps it.
+func (t *Tenant) DropClaimedResolvedXnames(ctx context.Context, reader client.Reader) ([]string, error) {
+	var conflicts []string
+	for i := range t.Status.ResolvedXnames {
+		resolved := &t.Status.ResolvedXnames[i]
+		var xnames []string
+		for _, xname := range resolved.Xnames {
+			tenants, err := ListTenantsByXname(ctx, reader, xname)
+			if err != nil {
+				return nil, err
+			}
+			claimed := false
+			for _, other := range tenants {
+				if other.Namespace != t.Namespace || other.Name != t.Name {
+					conflicts = append(conflicts, fmt.Sprintf("%s (tenant %s)", xname, other.Spec.TenantName))
+					claimed = true
+				}
+			}
+			if !claimed {
+				xnames = append(xnames, xname)
+			}
+		}
+		resolved.Xnames = xnames
+	}
+	return conflicts, nil
+}
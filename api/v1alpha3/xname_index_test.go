@@ -0,0 +1,93 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package v1alpha3
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type recordingIndexer struct {
+	field   string
+	extract client.IndexerFunc
+}
+
+func (i *recordingIndexer) IndexField(ctx context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
+	i.field, i.extract = field, extract
+	return nil
+}
+
+func TestValidateXnameClaims(t *testing.T) {
+	newTenant := func(name string, xnames ...[]string) *Tenant {
+		tenant := &Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenants"},
+			Spec:       TenantSpec{TenantName: name},
+		}
+		for _, resourceXnames := range xnames {
+			tenant.Spec.TenantResources = append(tenant.Spec.TenantResources, TenantResource{Type: "compute", Xnames: resourceXnames})
+		}
+		return tenant
+	}
+
+	indexer := &recordingIndexer{}
+	if err := IndexTenantXnames(context.Background(), indexer); err != nil {
+		t.Fatal(err)
+	}
+	xnames := indexer.extract(newTenant("vcluster-blue", []string{"x1", "x2"}, []string{"x2", "x3"}))
+	if indexer.field != TenantXnameField || !reflect.DeepEqual(xnames, []string{"x1", "x2", "x3"}) {
+		t.Errorf("unexpected index %s: %v", indexer.field, xnames)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	TenantIndexReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newTenant("vcluster-blue", []string{"x1"}),
+		newTenant("vcluster-red", []string{"x2"}),
+	).Build()
+	defer func() { TenantIndexReader = nil }()
+
+	// The tenant's own claims are not conflicts.
+	if err := newTenant("vcluster-blue", []string{"x1", "x3"}).ValidateXnameClaims(); err != nil {
+		t.Errorf("expected no conflict, got %v", err)
+	}
+	err := newTenant("vcluster-blue", []string{"x1"}, []string{"x2"}).ValidateXnameClaims()
+	if err == nil || !strings.Contains(err.Error(), "x2 (tenant vcluster-red)") {
+		t.Errorf("expected x2 to be claimed by vcluster-red, got %v", err)
+	}
+	if err := newTenant("vcluster-green", []string{"x1"}).ValidateXnameClaims(); err == nil {
+		t.Error("expected x1 to be claimed by vcluster-blue")
+	}
+}
@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2023-2025 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2023-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -47,7 +47,8 @@ limitations under the License.
 package v1alpha3
 
 import (
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -109,113 +110,199 @@ func (in *GlobalTenantHookList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmComponent) DeepCopyInto(out *HsmComponent) {
+func (in *GlobalTenantHookSpec) DeepCopyInto(out *GlobalTenantHookSpec) {
 	*out = *in
+	in.TenantHook.DeepCopyInto(&out.TenantHook)
+	if in.TenantSelector != nil {
+		in, out := &in.TenantSelector, &out.TenantSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceTypes != nil {
+		in, out := &in.ResourceTypes, &out.ResourceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedFields != nil {
+		in, out := &in.ChangedFields, &out.ChangedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmComponent.
-func (in *HsmComponent) DeepCopy() *HsmComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalTenantHookSpec.
+func (in *GlobalTenantHookSpec) DeepCopy() *GlobalTenantHookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmComponent)
+	out := new(GlobalTenantHookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmComponentList) DeepCopyInto(out *HsmComponentList) {
+func (in *HookDelivery) DeepCopyInto(out *HookDelivery) {
 	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make([]HsmComponent, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmComponentList.
-func (in *HsmComponentList) DeepCopy() *HsmComponentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookDelivery.
+func (in *HookDelivery) DeepCopy() *HookDelivery {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmComponentList)
+	out := new(HookDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HookDelivery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmGroup) DeepCopyInto(out *HsmGroup) {
+func (in *HookDeliveryList) DeepCopyInto(out *HookDeliveryList) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HookDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.Members.DeepCopyInto(&out.Members)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmGroup.
-func (in *HsmGroup) DeepCopy() *HsmGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookDeliveryList.
+func (in *HookDeliveryList) DeepCopy() *HookDeliveryList {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmGroup)
+	out := new(HookDeliveryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HookDeliveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmIds) DeepCopyInto(out *HsmIds) {
+func (in *HookDeliverySpec) DeepCopyInto(out *HookDeliverySpec) {
 	*out = *in
-	if in.Ids != nil {
-		in, out := &in.Ids, &out.Ids
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.Hook.DeepCopyInto(&out.Hook)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookDeliverySpec.
+func (in *HookDeliverySpec) DeepCopy() *HookDeliverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HookDeliverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookDeliveryStatus) DeepCopyInto(out *HookDeliveryStatus) {
+	*out = *in
+	if in.LastAttempt != nil {
+		in, out := &in.LastAttempt, &out.LastAttempt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccess != nil {
+		in, out := &in.LastSuccess, &out.LastSuccess
+		*out = (*in).DeepCopy()
 	}
+	if in.NextAttempt != nil {
+		in, out := &in.NextAttempt, &out.NextAttempt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookDeliveryStatus.
+func (in *HookDeliveryStatus) DeepCopy() *HookDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookPlan) DeepCopyInto(out *HookPlan) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmIds.
-func (in *HsmIds) DeepCopy() *HsmIds {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookPlan.
+func (in *HookPlan) DeepCopy() *HookPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmIds)
+	out := new(HookPlan)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmMemberId) DeepCopyInto(out *HsmMemberId) {
+func (in *HsmDrift) DeepCopyInto(out *HsmDrift) {
 	*out = *in
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unexpected != nil {
+		in, out := &in.Unexpected, &out.Unexpected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmMemberId.
-func (in *HsmMemberId) DeepCopy() *HsmMemberId {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmDrift.
+func (in *HsmDrift) DeepCopy() *HsmDrift {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmMemberId)
+	out := new(HsmDrift)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HsmPartition) DeepCopyInto(out *HsmPartition) {
+func (in *HsmPlan) DeepCopyInto(out *HsmPlan) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	if in.AddMembers != nil {
+		in, out := &in.AddMembers, &out.AddMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveMembers != nil {
+		in, out := &in.RemoveMembers, &out.RemoveMembers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.Members.DeepCopyInto(&out.Members)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmPartition.
-func (in *HsmPartition) DeepCopy() *HsmPartition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HsmPlan.
+func (in *HsmPlan) DeepCopy() *HsmPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(HsmPartition)
+	out := new(HsmPlan)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -235,6 +322,21 @@ func (in *KeycloakGroup) DeepCopy() *KeycloakGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakPlan) DeepCopyInto(out *KeycloakPlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakPlan.
+func (in *KeycloakPlan) DeepCopy() *KeycloakPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakRole) DeepCopyInto(out *KeycloakRole) {
 	*out = *in
@@ -250,6 +352,51 @@ func (in *KeycloakRole) DeepCopy() *KeycloakRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacePlan) DeepCopyInto(out *NamespacePlan) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePlan.
+func (in *NamespacePlan) DeepCopy() *NamespacePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerPlan) DeepCopyInto(out *PowerPlan) {
+	*out = *in
+	if in.Xnames != nil {
+		in, out := &in.Xnames, &out.Xnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerPlan.
+func (in *PowerPlan) DeepCopy() *PowerPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
@@ -277,10 +424,77 @@ func (in *Tenant) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantAppliedStatus) DeepCopyInto(out *TenantAppliedStatus) {
+	*out = *in
+	if in.ChildNamespaces != nil {
+		in, out := &in.ChildNamespaces, &out.ChildNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HsmPartitions != nil {
+		in, out := &in.HsmPartitions, &out.HsmPartitions
+		*out = make([]TenantHsmMembers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HsmGroups != nil {
+		in, out := &in.HsmGroups, &out.HsmGroups
+		*out = make([]TenantHsmMembers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantAppliedStatus.
+func (in *TenantAppliedStatus) DeepCopy() *TenantAppliedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantAppliedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantEventMetadata) DeepCopyInto(out *TenantEventMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantEventMetadata.
+func (in *TenantEventMetadata) DeepCopy() *TenantEventMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantEventMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantEventPayload) DeepCopyInto(out *TenantEventPayload) {
 	*out = *in
+	out.Metadata = in.Metadata
 	in.TenantSpec.DeepCopyInto(&out.TenantSpec)
+	if in.OldTenantSpec != nil {
+		in, out := &in.OldTenantSpec, &out.OldTenantSpec
+		*out = new(TenantSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]TenantResourceChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantStatus != nil {
+		in, out := &in.TenantStatus, &out.TenantStatus
+		*out = new(TenantStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantEventPayload.
@@ -313,6 +527,42 @@ func (in *TenantHook) DeepCopy() *TenantHook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantHsmMembers) DeepCopyInto(out *TenantHsmMembers) {
+	*out = *in
+	if in.Xnames != nil {
+		in, out := &in.Xnames, &out.Xnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantHsmMembers.
+func (in *TenantHsmMembers) DeepCopy() *TenantHsmMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantHsmMembers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantJournalEntry) DeepCopyInto(out *TenantJournalEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantJournalEntry.
+func (in *TenantJournalEntry) DeepCopy() *TenantJournalEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantJournalEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantKmsResource) DeepCopyInto(out *TenantKmsResource) {
 	*out = *in
@@ -375,6 +625,99 @@ func (in *TenantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPlan) DeepCopyInto(out *TenantPlan) {
+	*out = *in
+	in.Namespaces.DeepCopyInto(&out.Namespaces)
+	if in.HsmPartitions != nil {
+		in, out := &in.HsmPartitions, &out.HsmPartitions
+		*out = make([]HsmPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HsmGroups != nil {
+		in, out := &in.HsmGroups, &out.HsmGroups
+		*out = make([]HsmPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PowerOff != nil {
+		in, out := &in.PowerOff, &out.PowerOff
+		*out = make([]PowerPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Keycloak = in.Keycloak
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultPlan)
+		**out = **in
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookPlan, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPlan.
+func (in *TenantPlan) DeepCopy() *TenantPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPowerTransition) DeepCopyInto(out *TenantPowerTransition) {
+	*out = *in
+	if in.Xnames != nil {
+		in, out := &in.Xnames, &out.Xnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedXnames != nil {
+		in, out := &in.FailedXnames, &out.FailedXnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPowerTransition.
+func (in *TenantPowerTransition) DeepCopy() *TenantPowerTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPowerTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantResolvedXnames) DeepCopyInto(out *TenantResolvedXnames) {
+	*out = *in
+	if in.Xnames != nil {
+		in, out := &in.Xnames, &out.Xnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantResolvedXnames.
+func (in *TenantResolvedXnames) DeepCopy() *TenantResolvedXnames {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantResolvedXnames)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantResource) DeepCopyInto(out *TenantResource) {
 	*out = *in
@@ -383,6 +726,16 @@ func (in *TenantResource) DeepCopyInto(out *TenantResource) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.XnamePatterns != nil {
+		in, out := &in.XnamePatterns, &out.XnamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(TenantXnameSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantResource.
@@ -395,6 +748,31 @@ func (in *TenantResource) DeepCopy() *TenantResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantResourceChange) DeepCopyInto(out *TenantResourceChange) {
+	*out = *in
+	if in.AddedXnames != nil {
+		in, out := &in.AddedXnames, &out.AddedXnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedXnames != nil {
+		in, out := &in.RemovedXnames, &out.RemovedXnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantResourceChange.
+func (in *TenantResourceChange) DeepCopy() *TenantResourceChange {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantResourceChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
@@ -453,6 +831,39 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PowerTransitions != nil {
+		in, out := &in.PowerTransitions, &out.PowerTransitions
+		*out = make([]TenantPowerTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Journal != nil {
+		in, out := &in.Journal, &out.Journal
+		*out = make([]TenantJournalEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(TenantAppliedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResolvedXnames != nil {
+		in, out := &in.ResolvedXnames, &out.ResolvedXnames
+		*out = make([]TenantResolvedXnames, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
@@ -465,6 +876,41 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantXnameSelector) DeepCopyInto(out *TenantXnameSelector) {
+	*out = *in
+	if in.NIDs != nil {
+		in, out := &in.NIDs, &out.NIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantXnameSelector.
+func (in *TenantXnameSelector) DeepCopy() *TenantXnameSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantXnameSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPlan) DeepCopyInto(out *VaultPlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPlan.
+func (in *VaultPlan) DeepCopy() *VaultPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Xnames) DeepCopyInto(out *Xnames) {
 	{
@@ -0,0 +1,216 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clientretry "k8s.io/client-go/util/retry"
+
+	alphav3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// HookDeliveryReconciler delivers tenant events to notify hooks, retrying
+// failed deliveries with backoff.
+type HookDeliveryReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=tapms.hpe.com,resources=hookdeliveries,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=tapms.hpe.com,resources=hookdeliveries/status,verbs=get;update;patch
+
+// Reconcile makes a delivery attempt once the delivery is due, and deletes
+// completed deliveries once they have been kept for HookDeliveryRetention.
+func (r *HookDeliveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("hookdelivery", req.NamespacedName)
+	delivery := &alphav3.HookDelivery{}
+	err := r.Get(ctx, req.NamespacedName, delivery)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	switch delivery.Status.Phase {
+	case alphav3.HookDeliveryDelivered, alphav3.HookDeliveryFailed:
+		return r.expire(ctx, log, delivery, now)
+	}
+	if next := delivery.Status.NextAttempt; next != nil && now.Before(next.Time) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	hook := delivery.Spec.Hook
+	log.Info(fmt.Sprintf("Calling hook named '%s' at url %s (Notify)", hook.Name, hook.Url))
+	err = alphav3.DeliverHook(ctx, hook, delivery.Spec.SecretNamespace, delivery.Spec.EventType, []byte(delivery.Spec.Payload))
+
+	attempted := metav1.NewTime(now)
+	delivery.Status.LastAttempt = &attempted
+	delivery.Status.NextAttempt = nil
+	result := ctrl.Result{}
+	if err == nil {
+		log.Info(fmt.Sprintf("Notify call to '%s' hook at url %s called successfully", hook.Name, hook.Url))
+		delivery.Status.Phase = alphav3.HookDeliveryDelivered
+		delivery.Status.LastSuccess = &attempted
+		delivery.Status.LastError = ""
+		result.RequeueAfter = alphav3.HookDeliveryRetention
+	} else {
+		delivery.Status.Failures++
+		delivery.Status.LastError = err.Error()
+		if int(delivery.Status.Failures) >= alphav3.HookDeliveryBackoff.Steps {
+			log.Error(err, fmt.Sprintf("Giving up on delivery to '%s' hook at url %s", hook.Name, hook.Url))
+			r.Recorder.Eventf(delivery, corev1.EventTypeWarning, alphav3.HookDeliveryFailed,
+				"Failed to deliver %s event to hook %s after %d attempts: %s", delivery.Spec.EventType, hook.Name, delivery.Status.Failures, err)
+			delivery.Status.Phase = alphav3.HookDeliveryFailed
+			result.RequeueAfter = alphav3.HookDeliveryRetention
+		} else {
+			delay := alphav3.HookDeliveryBackoff.Delay(int(delivery.Status.Failures))
+			log.Info(fmt.Sprintf("Notify call to '%s' hook at url %s failed, retrying in %s: %s", hook.Name, hook.Url, delay.Round(time.Second), err))
+			next := metav1.NewTime(now.Add(delay))
+			delivery.Status.Phase = alphav3.HookDeliveryPending
+			delivery.Status.NextAttempt = &next
+			result.RequeueAfter = delay
+		}
+	}
+
+	err = r.Status().Update(ctx, delivery)
+	if err != nil {
+		log.Error(err, "Failed to update hook delivery status")
+		return ctrl.Result{}, err
+	}
+	if delivery.Status.Phase != alphav3.HookDeliveryPending {
+		r.updateTenant(ctx, log, delivery)
+	}
+	return result, nil
+}
+
+// expire deletes a completed delivery once it is older than the retention.
+func (r *HookDeliveryReconciler) expire(ctx context.Context, log logr.Logger, delivery *alphav3.HookDelivery, now time.Time) (ctrl.Result, error) {
+	completed := delivery.CreationTimestamp.Time
+	if delivery.Status.LastAttempt != nil {
+		completed = delivery.Status.LastAttempt.Time
+	}
+	if age := now.Sub(completed); age < alphav3.HookDeliveryRetention {
+		return ctrl.Result{RequeueAfter: alphav3.HookDeliveryRetention - age}, nil
+	}
+	log.Info("Deleting expired hook delivery")
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, delivery))
+}
+
+// updateTenant refreshes the HooksDelivered condition of the delivery's
+// tenant, which may since have been deleted.
+func (r *HookDeliveryReconciler) updateTenant(ctx context.Context, log logr.Logger, delivery *alphav3.HookDelivery) {
+	name := types.NamespacedName{Namespace: delivery.Namespace, Name: delivery.Labels[alphav3.HookDeliveryTenantLabel]}
+	err := clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+		tenant := &alphav3.Tenant{}
+		err := r.Get(ctx, name, tenant)
+		if err != nil {
+			return err
+		}
+		orig := tenant.Status.DeepCopy()
+		setHooksDelivered(ctx, log, r.Client, tenant)
+		if equality.Semantic.DeepEqual(orig, &tenant.Status) {
+			return nil
+		}
+		return r.Status().Update(ctx, tenant)
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		log.Error(err, "Failed to update tenant hook delivery status")
+	}
+}
+
+// setHooksDelivered sets the HooksDelivered condition from the most recent
+// delivery to each of the tenant's notify hooks. Blocking hooks are called
+// during admission, so have always succeeded by the time they get here.
+func setHooksDelivered(ctx context.Context, log logr.Logger, c client.Reader, t *alphav3.Tenant) {
+	deliveries := &alphav3.HookDeliveryList{}
+	err := c.List(ctx, deliveries, client.InNamespace(t.Namespace), client.MatchingLabels{alphav3.HookDeliveryTenantLabel: t.Name})
+	if err != nil {
+		log.Error(err, "Failed to list hook deliveries")
+		t.SetCondition(alphav3.ConditionHooksDelivered, metav1.ConditionUnknown, alphav3.ReasonFailed, err.Error())
+		return
+	}
+
+	latest := map[string]*alphav3.HookDelivery{}
+	for i := range deliveries.Items {
+		d := &deliveries.Items[i]
+		key := d.Spec.Hook.Name + " " + d.Spec.Hook.Url
+		if prev, ok := latest[key]; !ok || prev.CreationTimestamp.Before(&d.CreationTimestamp) {
+			latest[key] = d
+		}
+	}
+	var pending, failed []string
+	for _, d := range latest {
+		switch d.Status.Phase {
+		case alphav3.HookDeliveryFailed:
+			failed = append(failed, d.Spec.Hook.Name)
+		case alphav3.HookDeliveryDelivered:
+		default:
+			pending = append(pending, d.Spec.Hook.Name)
+		}
+	}
+
+	sort.Strings(failed)
+	sort.Strings(pending)
+	switch {
+	case len(failed) > 0:
+		t.SetCondition(alphav3.ConditionHooksDelivered, metav1.ConditionFalse, alphav3.ReasonFailed,
+			fmt.Sprintf("Failed to deliver events to hooks %v", failed))
+	case len(pending) > 0:
+		t.SetCondition(alphav3.ConditionHooksDelivered, metav1.ConditionFalse, alphav3.ReasonPending,
+			fmt.Sprintf("Delivering events to hooks %v", pending))
+	default:
+		t.SetCondition(alphav3.ConditionHooksDelivered, metav1.ConditionTrue, alphav3.ReasonSucceeded, "")
+	}
+}
+
+// hookDeliveryWorkers is how many deliveries are attempted at once, so a
+// slow hook only holds up its own deliveries.
+const hookDeliveryWorkers = 10
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HookDeliveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&alphav3.HookDelivery{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: hookDeliveryWorkers}).
+		Complete(r)
+}
@@ -0,0 +1,172 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+)
+
+func TestTenantHookDelivery(t *testing.T) {
+	calls := 0
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer hook.Close()
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"},
+		Spec:       v1alpha3.TenantSpec{TenantName: "vcluster-blue"},
+	}
+	delivery := &v1alpha3.HookDelivery{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vcluster-blue-create-abcde",
+			Namespace: "tenants",
+			Labels:    map[string]string{v1alpha3.HookDeliveryTenantLabel: "vcluster-blue"},
+		},
+		Spec: v1alpha3.HookDeliverySpec{
+			Hook:       v1alpha3.TenantHook{Name: "notify", Url: hook.URL, EventTypes: []string{"CREATE"}},
+			TenantName: "vcluster-blue",
+			EventType:  "CREATE",
+			Payload:    `{"eventtype":"CREATE"}`,
+		},
+	}
+	r := &HookDeliveryReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant, delivery).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: delivery.Name}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status.Phase != v1alpha3.HookDeliveryPending || delivery.Status.Failures != 1 || delivery.Status.LastError == "" {
+		t.Errorf("expected a pending delivery with one failure, got %+v", delivery.Status)
+	}
+	if result.RequeueAfter <= 0 || delivery.Status.NextAttempt == nil {
+		t.Errorf("expected the delivery to be retried later, got %+v", result)
+	}
+
+	// Not due yet.
+	if _, err := r.Reconcile(ctx, req); err != nil || calls != 1 {
+		t.Fatalf("expected no attempt before the next attempt time, got %d calls and %v", calls, err)
+	}
+
+	past := metav1.NewTime(time.Now().Add(-time.Second))
+	delivery.Status.NextAttempt = &past
+	if err := r.Status().Update(ctx, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status.Phase != v1alpha3.HookDeliveryDelivered || delivery.Status.LastSuccess == nil {
+		t.Errorf("expected a delivered delivery, got %+v", delivery.Status)
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if c := tenant.GetCondition(v1alpha3.ConditionHooksDelivered); c == nil || c.Status != metav1.ConditionTrue {
+		t.Errorf("expected HooksDelivered to be true, got %+v", c)
+	}
+}
+
+func TestHookDeliveryTimeout(t *testing.T) {
+	done := make(chan struct{})
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer hook.Close()
+	defer close(done)
+	timeout := v1alpha3.HookTimeout
+	v1alpha3.HookTimeout = 100 * time.Millisecond
+	defer func() { v1alpha3.HookTimeout = timeout }()
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	delivery := &v1alpha3.HookDelivery{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue-create-abcde", Namespace: "tenants"},
+		Spec: v1alpha3.HookDeliverySpec{
+			Hook:       v1alpha3.TenantHook{Name: "notify", Url: hook.URL, EventTypes: []string{"CREATE"}},
+			TenantName: "vcluster-blue",
+			EventType:  "CREATE",
+			Payload:    `{"eventtype":"CREATE"}`,
+		},
+	}
+	r := &HookDeliveryReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(delivery).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: delivery.Name}}
+
+	// A hook that never responds fails the attempt, which is retried later.
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status.Phase != v1alpha3.HookDeliveryPending || delivery.Status.Failures != 1 || result.RequeueAfter <= 0 {
+		t.Errorf("expected a timed out attempt to be retried, got %+v and %+v", delivery.Status, result)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	alphav3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	hsmDriftXnames = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tapms_tenant_hsm_drift_xnames",
+			Help: "Number of xnames whose HSM partition or group membership differs from the tenant spec",
+		},
+		[]string{"tenant", "kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(hsmDriftXnames)
+}
+
+// recordHsmDrift sets the drift gauges for a tenant.
+func recordHsmDrift(tenantName string, drift []alphav3.HsmDrift) {
+	counts := map[string]int{alphav3.HsmKindPartition: 0, alphav3.HsmKindGroup: 0}
+	for _, d := range drift {
+		counts[d.Kind] += d.Count()
+	}
+	for kind, count := range counts {
+		hsmDriftXnames.WithLabelValues(tenantName, kind).Set(float64(count))
+	}
+}
+
+// forgetHsmDrift removes the drift gauges of a deleted tenant.
+func forgetHsmDrift(tenantName string) {
+	hsmDriftXnames.DeleteLabelValues(tenantName, alphav3.HsmKindPartition)
+	hsmDriftXnames.DeleteLabelValues(tenantName, alphav3.HsmKindGroup)
+}
+
+var (
+	tenantsDesc = prometheus.NewDesc(
+		"tapms_tenants",
+		"Number of tenants, by lifecycle phase",
+		[]string{"phase"}, nil,
+	)
+	tenantNodesDesc = prometheus.NewDesc(
+		"tapms_tenant_nodes",
+		"Number of xnames applied to a tenant",
+		[]string{"tenant"}, nil,
+	)
+)
+
+// tenantCollector reports tenant gauges from the manager's cache at
+// scrape time, so they never go stale after a tenant is deleted.
+type tenantCollector struct {
+	client client.Reader
+	log    logr.Logger
+}
+
+// registerTenantCollector registers the tenant gauges, once per process.
+func registerTenantCollector(reader client.Reader, log logr.Logger) error {
+	err := metrics.Registry.Register(&tenantCollector{client: reader, log: log})
+	if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil
+	}
+	return err
+}
+
+func (c *tenantCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tenantsDesc
+	ch <- tenantNodesDesc
+}
+
+func (c *tenantCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tenants := &alphav3.TenantList{}
+	if err := c.client.List(ctx, tenants); err != nil {
+		c.log.Error(err, "Failed to list tenants for metrics")
+		return
+	}
+
+	phases := map[string]int{
+		alphav3.PhaseNew:       0,
+		alphav3.PhaseDeploying: 0,
+		alphav3.PhaseDeployed:  0,
+		alphav3.PhaseDeleting:  0,
+		alphav3.PhaseFailed:    0,
+	}
+	for _, t := range tenants.Items {
+		phase := t.Status.Phase
+		if phase == "" {
+			phase = alphav3.PhaseNew
+		}
+		phases[phase]++
+
+		xnames := map[string]bool{}
+		for _, resource := range t.Status.TenantResources {
+			for _, xname := range resource.Xnames {
+				xnames[xname] = true
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(tenantNodesDesc, prometheus.GaugeValue, float64(len(xnames)), t.Spec.TenantName)
+	}
+	for phase, count := range phases {
+		ch <- prometheus.MustNewConstMetric(tenantsDesc, prometheus.GaugeValue, float64(count), phase)
+	}
+}
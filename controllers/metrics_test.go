@@ -0,0 +1,83 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+)
+
+func TestTenantCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	blue := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants"},
+		Spec:       v1alpha3.TenantSpec{TenantName: "vcluster-blue"},
+		Status: v1alpha3.TenantStatus{
+			Phase: v1alpha3.PhaseDeployed,
+			TenantResources: []v1alpha3.TenantResource{
+				{Type: "compute", Xnames: []string{"x0c3s5b0n0", "x0c3s6b0n0"}},
+				{Type: "application", Xnames: []string{"x0c3s5b0n0"}},
+			},
+		},
+	}
+	red := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-red", Namespace: "tenants"},
+		Spec:       v1alpha3.TenantSpec{TenantName: "vcluster-red"},
+	}
+	collector := &tenantCollector{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(blue, red).Build(),
+		log:    ctrl.Log,
+	}
+
+	expected := `
+# HELP tapms_tenant_nodes Number of xnames applied to a tenant
+# TYPE tapms_tenant_nodes gauge
+tapms_tenant_nodes{tenant="vcluster-blue"} 2
+tapms_tenant_nodes{tenant="vcluster-red"} 0
+# HELP tapms_tenants Number of tenants, by lifecycle phase
+# TYPE tapms_tenants gauge
+tapms_tenants{phase="Deleting"} 0
+tapms_tenants{phase="Deployed"} 1
+tapms_tenants{phase="Deploying"} 0
+tapms_tenants{phase="Failed"} 0
+tapms_tenants{phase="New"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
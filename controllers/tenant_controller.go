@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -28,13 +28,20 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"reflect"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	alphav3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,13 +54,16 @@ const tenantFinalizer = "tapms.hpe.com/finalizer"
 // TenantReconciler reconciles a Tenant object
 type TenantReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=tapms.hpe.com,resources=tenants,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=tapms.hpe.com,resources=tenants/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tapms.hpe.com,resources=tenants/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -74,137 +84,430 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			log.Info("Tenant resource not found. Ignoring since object must be deleted")
 			return ctrl.Result{}, nil
 		}
+		return ctrl.Result{}, err
 	}
+	origStatus := tenant.Status.DeepCopy()
 
 	isTenantMarkedToBeDeleted := tenant.GetDeletionTimestamp() != nil
 	if !isTenantMarkedToBeDeleted {
+		if ready := tenant.GetCondition(alphav3.ConditionReady); ready != nil && ready.ObservedGeneration == tenant.Generation {
+			switch ready.Reason {
+			case alphav3.ReasonRollingBack:
+				return r.rollback(ctx, log, tenant)
+			case alphav3.ReasonRolledBack:
+				log.Info("Tenant was rolled back, waiting for the spec to change")
+				return ctrl.Result{}, nil
+			}
+		}
+
 		tenant.Spec.State = "Deploying"
-		result, err := alphav3.CreateSubanchorNs(ctx, log, r.Client, "tenants", tenant.Spec.TenantName)
+		tenant.Status.Phase = alphav3.PhaseDeploying
+
+		//
+		// Resolve xname patterns and selectors on every reconcile, so
+		// nodes added to or removed from HSM are picked up with the
+		// periodic drift check. Resolved xnames claimed by another tenant
+		// are dropped and fail the tenant, as admission only checked the
+		// xnames resolved then.
+		//
+		changed, err := alphav3.ResolveXnames(ctx, log, tenant)
+		if err != nil {
+			log.Error(err, "Failed to resolve xnames")
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionXnamesReady, err)
+		}
+		conflicts, err := tenant.DropClaimedResolvedXnames(ctx, r.Client)
 		if err != nil {
-			return result, err
+			log.Error(err, "Failed to check xname claims")
+			return ctrl.Result{}, err
+		}
+		if len(conflicts) > 0 {
+			err := fmt.Errorf("the following resolved xname(s) are already claimed by another tenant: %v", conflicts)
+			log.Error(err, "Failed to resolve xnames")
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionXnamesReady, err)
+		}
+		if len(tenant.Status.ResolvedXnames) == 0 {
+			tenant.SetCondition(alphav3.ConditionXnamesReady, metav1.ConditionTrue, alphav3.ReasonNotRequired, "")
+		} else {
+			tenant.SetCondition(alphav3.ConditionXnamesReady, metav1.ConditionTrue, alphav3.ReasonSucceeded, "")
+		}
+		if changed {
+			for _, resolved := range tenant.Status.ResolvedXnames {
+				r.Recorder.Eventf(tenant, corev1.EventTypeNormal, stepReason(alphav3.ConditionXnamesReady, eventReasonUpdated),
+					"Resolved %d xname(s) for resource type %s", len(resolved.Xnames), resolved.Type)
+			}
+		}
+
+		result, created, err := alphav3.CreateSubanchorNs(ctx, log, r.Client, "tenants", tenant.Spec.TenantName)
+		if err != nil {
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionNamespacesReady, err)
+		} else if created {
+			tenant.RecordCreated(alphav3.ConditionNamespacesReady, alphav3.JournalKindNamespace, tenant.Spec.TenantName)
 		} else if result.Requeue {
-			return result, nil
+			return r.stepPending(ctx, log, tenant, alphav3.ConditionNamespacesReady, result, "Waiting for HNC to create namespace "+tenant.Spec.TenantName)
 		}
 
 		if tenant.Spec.ChildNamespaces != nil {
 			for _, childNamespace := range tenant.Spec.ChildNamespaces {
 				childNs := alphav3.GetChildNamespaceName(tenant.Spec.TenantName, childNamespace)
-				result, err := alphav3.CreateSubanchorNs(ctx, log, r.Client, tenant.Spec.TenantName, childNs)
+				result, created, err := alphav3.CreateSubanchorNs(ctx, log, r.Client, tenant.Spec.TenantName, childNs)
 				if err != nil {
-					return result, err
+					return r.stepFailed(ctx, log, tenant, alphav3.ConditionNamespacesReady, err)
+				} else if created {
+					tenant.RecordCreated(alphav3.ConditionNamespacesReady, alphav3.JournalKindNamespace, childNs)
 				} else if result.Requeue {
-					return result, nil
+					return r.stepPending(ctx, log, tenant, alphav3.ConditionNamespacesReady, result, "Waiting for HNC to create namespace "+childNs)
 				}
+				tenant.SetChildNamespaceApplied(childNs, true)
 			}
 		}
 
-		for _, resource := range tenant.Spec.TenantResources {
+		if deletedChildNamespaces := alphav3.DeletedChildNamespaces(tenant); len(deletedChildNamespaces) > 0 {
+			//
+			// Don't need to add members, that gets handled above in the create loop
+			//
+			_, err = alphav3.DeleteChildNamespaces(ctx, log, r.Client, tenant, deletedChildNamespaces)
+			if err != nil {
+				log.Error(err, "Failed to delete child namespaces")
+				return r.stepFailed(ctx, log, tenant, alphav3.ConditionNamespacesReady, err)
+			}
+			for _, childNamespace := range deletedChildNamespaces {
+				r.Recorder.Eventf(tenant, corev1.EventTypeNormal, stepReason(alphav3.ConditionNamespacesReady, eventReasonDeleted),
+					"Deleted child namespace %s", alphav3.GetChildNamespaceName(tenant.Spec.TenantName, childNamespace))
+			}
+		}
+		r.stepSucceeded(tenant, alphav3.ConditionNamespacesReady, alphav3.ReasonSucceeded, "namespaces")
+
+		//
+		// Power off xnames changing tenants before they are moved
+		// between HSM partitions and groups.
+		//
+		if alphav3.HasPowerPolicy(tenant) {
+			result, err := alphav3.EnsurePowerState(ctx, log, tenant)
+			if err != nil {
+				log.Error(err, "Failed to power off xnames")
+				return r.stepFailed(ctx, log, tenant, alphav3.ConditionPowerReady, err)
+			} else if result.RequeueAfter > 0 {
+				message := fmt.Sprintf("Waiting for PCS power transition(s) %v", alphav3.PendingPowerTransitions(tenant))
+				return r.stepPending(ctx, log, tenant, alphav3.ConditionPowerReady, result, message)
+			}
+			r.stepSucceeded(tenant, alphav3.ConditionPowerReady, alphav3.ReasonSucceeded, "power state")
+		} else {
+			r.stepSucceeded(tenant, alphav3.ConditionPowerReady, alphav3.ReasonNotRequired, "power state")
+		}
+
+		partitionReason := alphav3.ReasonNotRequired
+		for _, resource := range tenant.ResolvedResources() {
 			if len(resource.HsmPartitionName) > 0 {
 				log.Info(fmt.Sprintf("Creating/updating HSM partition for %s and resource type %s", tenant.Spec.TenantName, resource.Type))
-				result, err := alphav3.UpdateHSMPartition(ctx, log, tenant, resource.HsmPartitionName, resource.Xnames)
+				_, err := alphav3.UpdateHSMPartition(ctx, log, tenant, resource.HsmPartitionName, resource.Xnames)
 				if err != nil {
 					log.Error(err, "Failed to create/update HSM partition")
-					return result, err
+					return r.stepFailed(ctx, log, tenant, alphav3.ConditionHsmPartitionReady, err)
 				}
+				partitionReason = alphav3.ReasonSucceeded
 			}
 		}
+		r.stepSucceeded(tenant, alphav3.ConditionHsmPartitionReady, partitionReason, "HSM partitions")
 
-		result, err = alphav3.DetermineHSMGroupChanges(ctx, log, tenant)
+		_, err = alphav3.DetermineHSMGroupChanges(ctx, log, tenant)
 		if err != nil {
 			log.Error(err, "Failed to create/update HSM group")
-			return result, err
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionHsmGroupReady, err)
 		}
+		r.stepSucceeded(tenant, alphav3.ConditionHsmGroupReady, alphav3.ReasonSucceeded, "HSM groups")
 
 		log.Info("Creating/updating Keycloak Group for: " + tenant.Spec.TenantName)
-		result, err = alphav3.UpdateKeycloakGroup(ctx, log, tenant)
+		_, err = alphav3.UpdateKeycloakGroup(ctx, log, tenant)
 		if err != nil {
 			log.Error(err, "Failed to create/update Keycloak Group")
-			return result, err
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionKeycloakReady, err)
 		}
+		r.stepSucceeded(tenant, alphav3.ConditionKeycloakReady, alphav3.ReasonSucceeded, "Keycloak group")
 
 		log.Info("Creating/updating Vault transit for: " + tenant.Spec.TenantName)
-		result, err = alphav3.CreateVaultTransit(ctx, log, tenant)
+		_, err = alphav3.CreateVaultTransit(ctx, log, tenant)
 		if err != nil {
 			log.Error(err, "Failed to create/update Vault transit")
-			return result, err
+			return r.stepFailed(ctx, log, tenant, alphav3.ConditionVaultKmsReady, err)
+		}
+		if tenant.Spec.TenantKmsResource.Enabled {
+			r.stepSucceeded(tenant, alphav3.ConditionVaultKmsReady, alphav3.ReasonSucceeded, "Vault transit engine")
+		} else {
+			r.stepSucceeded(tenant, alphav3.ConditionVaultKmsReady, alphav3.ReasonNotRequired, "Vault transit engine")
 		}
 
-		if !reflect.DeepEqual(alphav3.TranslateStatusNamespacesForSpec(tenant.Status.ChildNamespaces), tenant.Spec.ChildNamespaces) {
-			//
-			// Don't need to add members, that gets handled above in the create loop
-			//
-			deletedChildNamespaces := alphav3.Difference(alphav3.TranslateStatusNamespacesForSpec(tenant.Status.ChildNamespaces), tenant.Spec.ChildNamespaces)
-			alphav3.DeleteChildNamespaces(ctx, log, r.Client, tenant, deletedChildNamespaces)
+		setHooksDelivered(ctx, log, r.Client, tenant)
+
+		tenant.SetCondition(alphav3.ConditionReady, metav1.ConditionTrue, alphav3.ReasonSucceeded, "")
+		tenant.Status.Phase = alphav3.PhaseDeployed
+		tenant.Status.ObservedGeneration = tenant.Generation
+		tenant.Status.Journal = nil
+
+		r.checkHsmDrift(ctx, log, tenant)
+
+		updated := alphav3.TenantIsUpdated(tenant)
+		if updated {
+			log.Info("Updating tenant status")
+			tenant.Status.TenantResources = tenant.ResolvedResources()
+			tenant.Status.TenantHooks = tenant.Spec.TenantHooks
+			tenant.Status.ChildNamespaces = alphav3.TranslateSpecNamespacesForStatus(tenant.Spec.TenantName, tenant.Spec.ChildNamespaces)
+		}
+
+		//
+		// PROVISIONED is queued once per generation of the spec, before
+		// the generation it was queued for is recorded. Tenants deployed
+		// before this was recorded already had it queued.
+		//
+		if tenant.Status.ProvisionedGeneration == 0 && origStatus.Phase == alphav3.PhaseDeployed && origStatus.ObservedGeneration == tenant.Generation {
+			tenant.Status.ProvisionedGeneration = tenant.Generation
+		}
+		if tenant.Status.ProvisionedGeneration != tenant.Generation {
+			err = alphav3.QueueLifecycleHooks(ctx, tenant, log, alphav3.EventProvisioned)
 			if err != nil {
-				log.Error(err, "Failed to delete child namespaces")
+				log.Error(err, "Failed to queue PROVISIONED hook deliveries")
 				return ctrl.Result{}, err
 			}
+			tenant.Status.ProvisionedGeneration = tenant.Generation
+			r.Recorder.Eventf(tenant, corev1.EventTypeNormal, alphav3.PhaseDeployed, "Tenant %s deployed", tenant.Spec.TenantName)
 		}
 
-		if alphav3.TenantIsUpdated(tenant) {
-			log.Info("Updating tenant status")
-			//
-			// Grab a fresh copy of the tenant to ensure we have
-			// the latest resource version id, as the above API calls
-			// can take a while.
-			//
-			//freshTenant := &alphav3.Tenant{}
-			//r.Get(ctx, req.NamespacedName, freshTenant)
-			tenant.Status.TenantResources = tenant.Spec.TenantResources
-			tenant.Status.TenantHooks = tenant.Spec.TenantHooks
-			tenant.Status.ChildNamespaces = alphav3.TranslateSpecNamespacesForStatus(tenant.Spec.TenantName, tenant.Spec.ChildNamespaces)
+		if !equality.Semantic.DeepEqual(origStatus, &tenant.Status) {
 			err = r.Status().Update(ctx, tenant)
 			if err != nil {
-				log.Error(err, "Failed to update final tenant status")
+				log.Error(err, "Failed to update tenant status")
 				return ctrl.Result{}, err
 			}
-			err = r.Update(ctx, tenant)
+		}
+		if updated {
+			err = r.patchTenant(ctx, tenant, func() { tenant.Spec.State = "Deployed" })
 			if err != nil {
-				log.Error(err, "Failed to update tenant resource")
+				log.Error(err, "Failed to update tenant state")
 				return ctrl.Result{}, err
 			}
 		}
 
 	} else {
-		tenant.Spec.State = "Deleting"
-		err = r.Update(ctx, tenant)
+		err = r.patchTenant(ctx, tenant, func() { tenant.Spec.State = "Deleting" })
 		if err != nil {
 			log.Error(err, "Failed to update tenant state")
 			return ctrl.Result{Requeue: true}, err
 		}
 		if controllerutil.ContainsFinalizer(tenant, tenantFinalizer) {
+			if tenant.Status.Phase != alphav3.PhaseDeleting {
+				r.Recorder.Eventf(tenant, corev1.EventTypeNormal, alphav3.PhaseDeleting, "Deleting tenant %s", tenant.Spec.TenantName)
+				tenant.Status.Phase = alphav3.PhaseDeleting
+				tenant.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, alphav3.ReasonDeleting, "Tenant is being deleted")
+				err = r.Status().Update(ctx, tenant)
+				if err != nil {
+					log.Error(err, "Failed to update tenant status")
+					return ctrl.Result{}, err
+				}
+			}
+
 			// Run finalization logic for tenantFinalizer. If the
 			// finalization logic fails, don't remove the finalizer so
 			// that we can retry during the next reconciliation.
 			result, err := r.finalizeTenant(ctx, log, tenant)
 			if err != nil {
-				return result, err
+				message := fmt.Sprintf("Failed to delete tenant: %s", err)
+				prev := tenant.GetCondition(alphav3.ConditionReady)
+				reported := prev != nil && prev.Message == message
+				tenant.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, alphav3.ReasonDeleting, message)
+				if statusErr := r.Status().Update(ctx, tenant); statusErr != nil {
+					log.Error(statusErr, "Failed to update tenant status")
+				}
+				if !reported && !retry.IsRetryable(err) {
+					r.queueLifecycleHooks(ctx, log, tenant, alphav3.EventFailed)
+				}
+				return requeueTransient(log, err)
 			} else if result.Requeue {
 				return result, nil
 			}
 
+			forgetHsmDrift(tenant.Spec.TenantName)
+
 			// Remove tenantFinalizer. Once all finalizers have been
 			// removed, the object will be deleted.
-			controllerutil.RemoveFinalizer(tenant, tenantFinalizer)
-			err = r.Update(ctx, tenant)
+			err = r.patchTenant(ctx, tenant, func() { controllerutil.RemoveFinalizer(tenant, tenantFinalizer) })
 			if err != nil {
 				log.Error(err, "Failed to remove finalizer")
 				return ctrl.Result{}, err
 			}
+			r.queueLifecycleHooks(ctx, log, tenant, alphav3.EventDeprovisioned)
 		}
 		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer for this CR
 	if !controllerutil.ContainsFinalizer(tenant, tenantFinalizer) {
-		controllerutil.AddFinalizer(tenant, tenantFinalizer)
-		err = r.Update(ctx, tenant)
+		err = r.patchTenant(ctx, tenant, func() { controllerutil.AddFinalizer(tenant, tenantFinalizer) })
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue to periodically check HSM for drift.
+	return ctrl.Result{RequeueAfter: alphav3.GetDriftCheckInterval()}, nil
+}
+
+// patchTenant writes the changes mutate makes to the tenant's spec or
+// metadata as a merge patch, so concurrent edits to the rest of the
+// tenant are kept. The status is only written through the status
+// subresource.
+func (r *TenantReconciler) patchTenant(ctx context.Context, t *alphav3.Tenant, mutate func()) error {
+	patch := client.MergeFrom(t.DeepCopy())
+	mutate()
+	return r.Patch(ctx, t, patch)
+}
+
+// checkHsmDrift compares the tenant's HSM partitions and groups with the
+// spec, recording the result in the HsmInSync condition. With the enforce
+// drift policy, any drift is also repaired.
+func (r *TenantReconciler) checkHsmDrift(ctx context.Context, log logr.Logger, t *alphav3.Tenant) {
+	drift, err := alphav3.DetectHSMDrift(ctx, log, t)
+	if err != nil {
+		log.Error(err, "Failed to check HSM for drift")
+		t.SetCondition(alphav3.ConditionHsmInSync, metav1.ConditionUnknown, alphav3.ReasonFailed, err.Error())
+		return
+	}
+	recordHsmDrift(t.Spec.TenantName, drift)
+	if len(drift) == 0 {
+		t.SetCondition(alphav3.ConditionHsmInSync, metav1.ConditionTrue, alphav3.ReasonSucceeded, "")
+		return
+	}
+
+	summary := alphav3.DriftSummary(drift)
+	if t.Spec.DriftPolicy != alphav3.DriftPolicyEnforce {
+		prev := t.GetCondition(alphav3.ConditionHsmInSync)
+		if prev == nil || prev.Reason != alphav3.ReasonDriftDetected || prev.Message != summary {
+			r.Recorder.Event(t, corev1.EventTypeWarning, "HsmDriftDetected", summary)
+		}
+		t.SetCondition(alphav3.ConditionHsmInSync, metav1.ConditionFalse, alphav3.ReasonDriftDetected, summary)
+		return
+	}
+
+	err = alphav3.RepairHSMDrift(ctx, log, t, drift)
+	if err != nil {
+		log.Error(err, "Failed to repair HSM drift")
+		r.Recorder.Event(t, corev1.EventTypeWarning, "HsmDriftRepairFailed", err.Error())
+		t.SetCondition(alphav3.ConditionHsmInSync, metav1.ConditionFalse, alphav3.ReasonFailed, err.Error())
+		return
+	}
+	r.Recorder.Event(t, corev1.EventTypeWarning, "HsmDriftRepaired", summary)
+	t.SetCondition(alphav3.ConditionHsmInSync, metav1.ConditionTrue, alphav3.ReasonDriftRepaired, summary)
+}
+
+// Event reasons are prefixed with the step they refer to, e.g.
+// HsmGroupCreated or KeycloakDeleteFailed.
+const (
+	eventReasonCreated      = "Created"
+	eventReasonUpdated      = "Updated"
+	eventReasonDeleted      = "Deleted"
+	eventReasonDeleteFailed = "DeleteFailed"
+)
+
+// stepSucceeded records a completed provisioning step in the tenant
+// status, emitting an event the first time the step completes for the
+// current generation of the tenant spec.
+func (r *TenantReconciler) stepSucceeded(t *alphav3.Tenant, conditionType string, reason string, what string) {
+	var prev *metav1.Condition
+	if c := t.GetCondition(conditionType); c != nil {
+		prev = c.DeepCopy()
+	}
+	t.SetCondition(conditionType, metav1.ConditionTrue, reason, "")
+	if reason == alphav3.ReasonNotRequired {
+		return
+	}
+	if prev != nil && prev.Status == metav1.ConditionTrue && prev.Reason == reason && prev.ObservedGeneration == t.Generation {
+		return
+	}
+	if t.Status.ObservedGeneration == 0 {
+		r.Recorder.Eventf(t, corev1.EventTypeNormal, stepReason(conditionType, eventReasonCreated), "Created %s for tenant %s", what, t.Spec.TenantName)
+	} else {
+		r.Recorder.Eventf(t, corev1.EventTypeNormal, stepReason(conditionType, eventReasonUpdated), "Updated %s for tenant %s", what, t.Spec.TenantName)
+	}
+}
+
+// stepFailed records a failed provisioning step in the tenant status
+// and returns the error so the request is retried. Transient backend
+// failures are requeued after a delay instead, see requeueTransient.
+// Other failures put the tenant in the Failed phase, and the first for
+// each generation of the spec is sent to hooks as a FAILED event. With
+// the rollback failure policy, the tenant is then rolled back.
+func (r *TenantReconciler) stepFailed(ctx context.Context, log logr.Logger, t *alphav3.Tenant, conditionType string, err error) (ctrl.Result, error) {
+	reason := stepReason(conditionType, alphav3.ReasonFailed)
+	permanent := !retry.IsRetryable(err)
+
+	r.Recorder.Event(t, corev1.EventTypeWarning, reason, err.Error())
+	t.SetCondition(conditionType, metav1.ConditionFalse, alphav3.ReasonFailed, err.Error())
+	t.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, reason, err.Error())
+	if permanent {
+		t.Status.Phase = alphav3.PhaseFailed
+	}
+	if permanent && t.Status.FailedGeneration != t.Generation {
+		if queueErr := alphav3.QueueLifecycleHooks(ctx, t, log, alphav3.EventFailed); queueErr != nil {
+			log.Error(queueErr, "Failed to queue FAILED hook deliveries")
+		} else {
+			t.Status.FailedGeneration = t.Generation
+		}
+	}
+	if statusErr := r.Status().Update(ctx, t); statusErr != nil {
+		log.Error(statusErr, "Failed to update tenant status")
+	}
+	if permanent && t.RollbackOnFailure() {
+		return r.rollback(ctx, log, t)
+	}
+	return requeueTransient(log, err)
+}
+
+// queueLifecycleHooks queues a lifecycle event for delivery to the
+// tenant's hooks. The tenant is not requeued if this fails, since it has
+// otherwise been reconciled.
+func (r *TenantReconciler) queueLifecycleHooks(ctx context.Context, log logr.Logger, t *alphav3.Tenant, event string) {
+	err := alphav3.QueueLifecycleHooks(ctx, t, log, event)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Failed to queue %s hook deliveries", event))
+	}
+}
+
+// transientRequeueDelay is how long to wait before reconciling a tenant
+// again after a backend failed with a retryable error.
+const transientRequeueDelay = 30 * time.Second
+
+// requeueTransient returns err, unless it is a transient backend failure
+// that the backend clients already retried. Those are requeued after a
+// delay (or once the backend's circuit closes) rather than returned, so a
+// flaky API gateway does not cause rapid requeues.
+func requeueTransient(log logr.Logger, err error) (ctrl.Result, error) {
+	if !retry.IsRetryable(err) {
+		return ctrl.Result{}, err
+	}
+	delay := transientRequeueDelay
+	var open *retry.OpenError
+	if errors.As(err, &open) {
+		delay = open.RetryAfter
+	}
+	log.Info(fmt.Sprintf("Backend unavailable, retrying in %s: %s", delay.Round(time.Second), err))
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
+
+// stepReason builds the Ready condition reason for a step, e.g.
+// HsmGroupReady and Failed yields HsmGroupFailed.
+func stepReason(conditionType string, reason string) string {
+	return strings.TrimSuffix(conditionType, "Ready") + reason
+}
+
+// stepPending records a provisioning step that is waiting on another
+// controller (e.g. HNC) in the tenant status and returns result.
+func (r *TenantReconciler) stepPending(ctx context.Context, log logr.Logger, t *alphav3.Tenant, conditionType string, result ctrl.Result, message string) (ctrl.Result, error) {
+	r.Recorder.Event(t, corev1.EventTypeNormal, stepReason(conditionType, alphav3.ReasonPending), message)
+	t.SetCondition(conditionType, metav1.ConditionFalse, alphav3.ReasonPending, message)
+	t.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, stepReason(conditionType, alphav3.ReasonPending), message)
+	if statusErr := r.Status().Update(ctx, t); statusErr != nil {
+		log.Error(statusErr, "Failed to update tenant status")
+		return ctrl.Result{}, statusErr
+	}
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -215,7 +518,7 @@ func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return registerTenantCollector(mgr.GetClient(), r.Log)
 }
 
 func (r *TenantReconciler) BuildRootTreeStructure(mgr ctrl.Manager) error {
@@ -265,6 +568,7 @@ func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger,
 	//
 	result, err := alphav3.DeleteChildNamespaces(ctx, log, r.Client, t, t.Spec.ChildNamespaces)
 	if err != nil {
+		r.deleteFailed(t, alphav3.ConditionNamespacesReady, err)
 		return result, err
 	}
 
@@ -282,9 +586,11 @@ func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger,
 			return ctrl.Result{Requeue: true}, nil
 		} else {
 			log.Error(err, "Failed to delete parent namespace: "+t.Spec.TenantName)
+			r.deleteFailed(t, alphav3.ConditionNamespacesReady, err)
 			return ctrl.Result{}, err
 		}
 	}
+	r.deleted(t, alphav3.ConditionNamespacesReady, "Deleted namespaces for tenant %s", t.Spec.TenantName)
 
 	for _, resource := range t.Spec.TenantResources {
 		if len(resource.HsmPartitionName) > 0 {
@@ -292,8 +598,10 @@ func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger,
 			result, err = alphav3.DeleteHSMPartition(ctx, log, resource.HsmPartitionName)
 			if err != nil {
 				log.Error(err, "Failed to delete HSM partition")
+				r.deleteFailed(t, alphav3.ConditionHsmPartitionReady, err)
 				return result, err
 			}
+			r.deleted(t, alphav3.ConditionHsmPartitionReady, "Deleted HSM partition %s", resource.HsmPartitionName)
 		}
 	}
 
@@ -303,8 +611,10 @@ func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger,
 			result, err = alphav3.DeleteHSMGroup(ctx, log, resource.HsmGroupLabel)
 			if err != nil {
 				log.Error(err, "Failed to delete HSM group")
+				r.deleteFailed(t, alphav3.ConditionHsmGroupReady, err)
 				return result, err
 			}
+			r.deleted(t, alphav3.ConditionHsmGroupReady, "Deleted HSM group %s", resource.HsmGroupLabel)
 		}
 	}
 
@@ -312,15 +622,110 @@ func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger,
 	result, err = alphav3.DeleteKeycloakGroup(ctx, log, t)
 	if err != nil {
 		log.Error(err, "Failed to delete Keycloak group")
+		r.deleteFailed(t, alphav3.ConditionKeycloakReady, err)
 		return result, err
 	}
+	r.deleted(t, alphav3.ConditionKeycloakReady, "Deleted Keycloak group for tenant %s", t.Spec.TenantName)
 
 	log.Info("Deleting Vault transit for: " + t.Spec.TenantName)
 	result, err = alphav3.DeleteVaultTransit(ctx, log, t)
 	if err != nil {
 		log.Error(err, "Failed to delete Vault transit")
+		r.deleteFailed(t, alphav3.ConditionVaultKmsReady, err)
 		return result, err
 	}
+	if t.Spec.TenantKmsResource.Enabled {
+		r.deleted(t, alphav3.ConditionVaultKmsReady, "Deleted Vault transit engine for tenant %s", t.Spec.TenantName)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleted emits an event for a backend resource removed while
+// finalizing the tenant.
+func (r *TenantReconciler) deleted(t *alphav3.Tenant, conditionType string, messageFmt string, args ...interface{}) {
+	r.Recorder.Eventf(t, corev1.EventTypeNormal, stepReason(conditionType, eventReasonDeleted), messageFmt, args...)
+}
+
+// deleteFailed emits a warning event for a backend resource that could
+// not be removed while finalizing the tenant.
+func (r *TenantReconciler) deleteFailed(t *alphav3.Tenant, conditionType string, err error) {
+	r.Recorder.Event(t, corev1.EventTypeWarning, stepReason(conditionType, eventReasonDeleteFailed), err.Error())
+}
+
+// rollback deletes the backend resources recorded in the step journal of
+// a tenant that failed to provision, newest first. Entries are removed
+// from the journal as they are deleted, so a rollback that fails part way
+// is resumed by the next reconcile. The tenant then stays in the Failed
+// phase until its spec changes.
+func (r *TenantReconciler) rollback(ctx context.Context, log logr.Logger, t *alphav3.Tenant) (ctrl.Result, error) {
+	for len(t.Status.Journal) > 0 {
+		entry := t.Status.Journal[len(t.Status.Journal)-1]
+		log.Info(fmt.Sprintf("Rolling back %s %s for tenant %s", entry.Kind, entry.Name, t.Spec.TenantName))
+		result, err := r.undo(ctx, log, t, entry)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to roll back %s %s", entry.Kind, entry.Name))
+			r.deleteFailed(t, entry.Step, err)
+			message := fmt.Sprintf("Failed to delete %s %s: %s", entry.Kind, entry.Name, err)
+			t.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, alphav3.ReasonRollingBack, message)
+			if statusErr := r.Status().Update(ctx, t); statusErr != nil {
+				log.Error(statusErr, "Failed to update tenant status")
+			}
+			return requeueTransient(log, err)
+		} else if result.Requeue {
+			message := fmt.Sprintf("Waiting for HNC to delete %s %s", entry.Kind, entry.Name)
+			t.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, alphav3.ReasonRollingBack, message)
+			if statusErr := r.Status().Update(ctx, t); statusErr != nil {
+				log.Error(statusErr, "Failed to update tenant status")
+				return ctrl.Result{}, statusErr
+			}
+			return result, nil
+		}
+		r.deleted(t, entry.Step, "Deleted %s %s", entry.Kind, entry.Name)
+		t.SetCondition(entry.Step, metav1.ConditionFalse, alphav3.ReasonRolledBack, fmt.Sprintf("Deleted %s %s", entry.Kind, entry.Name))
+		t.Status.Journal = t.Status.Journal[:len(t.Status.Journal)-1]
+	}
+
+	r.Recorder.Eventf(t, corev1.EventTypeNormal, alphav3.ReasonRolledBack, "Rolled back tenant %s", t.Spec.TenantName)
+	t.Status.Phase = alphav3.PhaseFailed
+	t.Status.Applied = nil
+	t.SetCondition(alphav3.ConditionReady, metav1.ConditionFalse, alphav3.ReasonRolledBack,
+		"Deleted the resources created for the tenant after it failed to provision")
+	if statusErr := r.Status().Update(ctx, t); statusErr != nil {
+		log.Error(statusErr, "Failed to update tenant status")
+		return ctrl.Result{}, statusErr
+	}
+	return ctrl.Result{}, nil
+}
 
+// undo deletes a backend resource recorded in the step journal.
+func (r *TenantReconciler) undo(ctx context.Context, log logr.Logger, t *alphav3.Tenant, entry alphav3.TenantJournalEntry) (ctrl.Result, error) {
+	switch entry.Kind {
+	case alphav3.JournalKindNamespace:
+		parentNs := "tenants"
+		if entry.Name != t.Spec.TenantName {
+			parentNs = t.Spec.TenantName
+		}
+		err := r.Client.Delete(ctx, alphav3.SubNSAnchorForTenant(parentNs, entry.Name))
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		} else if k8serrors.IsForbidden(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	case alphav3.JournalKindHsmPartition:
+		return alphav3.DeleteHSMPartition(ctx, log, entry.Name)
+	case alphav3.JournalKindHsmGroup:
+		return alphav3.DeleteHSMGroup(ctx, log, entry.Name)
+	case alphav3.JournalKindKeycloakGroup:
+		return alphav3.DeleteKeycloakGroup(ctx, log, t)
+	case alphav3.JournalKindVaultTransit:
+		result, err := alphav3.DeleteVaultTransit(ctx, log, t)
+		if err == nil {
+			t.Status.TenantKmsStatus = alphav3.TenantKmsStatus{}
+		}
+		return result, err
+	}
+	log.Info(fmt.Sprintf("Ignoring unknown journal entry %s %s", entry.Kind, entry.Name))
 	return ctrl.Result{}, nil
 }
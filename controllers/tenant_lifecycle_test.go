@@ -0,0 +1,643 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	hncapi "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+
+	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/fakes"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/operatorconfig"
+)
+
+// fakesConfig returns an operator config pointing at the fake backends.
+func fakesConfig(hsmFake *fakes.Hsm, pcsFake *fakes.Pcs, keycloakFake *fakes.Keycloak, vaultFake *fakes.Vault) *operatorconfig.Config {
+	cfg := operatorconfig.Default()
+	cfg.Keycloak.URL = keycloakFake.URL
+	cfg.Keycloak.GatewayURL = keycloakFake.URL
+	cfg.Hsm.URL = hsmFake.URL
+	cfg.Pcs.URL = pcsFake.URL
+	cfg.Vault.URL = vaultFake.URL
+	return cfg
+}
+
+// startFakes starts fake backends and points the v1alpha3 clients at them.
+func startFakes(t *testing.T) (*fakes.Hsm, *fakes.Pcs, *fakes.Keycloak, *fakes.Vault) {
+	hsmFake, pcsFake, keycloakFake, vaultFake := fakes.NewHsm(), fakes.NewPcs(), fakes.NewKeycloak(), fakes.NewVault()
+	if err := v1alpha3.Configure(fakesConfig(hsmFake, pcsFake, keycloakFake, vaultFake)); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VAULT_TOKEN", "root")
+	v1alpha3.KeycloakSecretsReader = fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "services", Name: "keycloak-master-admin-auth"},
+			Data:       map[string][]byte{"client-id": []byte("admin-cli"), "user": []byte("admin"), "password": []byte("secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "admin-client-auth"},
+			Data:       map[string][]byte{"client-id": []byte("admin-client"), "client-secret": []byte("secret")},
+		},
+	).Build()
+	t.Cleanup(func() {
+		hsmFake.Close()
+		pcsFake.Close()
+		keycloakFake.Close()
+		vaultFake.Close()
+		v1alpha3.Configure(operatorconfig.Default())
+		v1alpha3.KeycloakSecretsReader = nil
+	})
+	return hsmFake, pcsFake, keycloakFake, vaultFake
+}
+
+func TestTenantLifecycle(t *testing.T) {
+	hsmFake, pcsFake, keycloakFake, vaultFake := startFakes(t)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName:      "vcluster-blue",
+			ChildNamespaces: []string{"slurm"},
+			TenantResources: []v1alpha3.TenantResource{{
+				Type:                      "compute",
+				Xnames:                    []string{"x0c3s5b0n0", "x0c3s6b0n0"},
+				HsmPartitionName:          "blue",
+				HsmGroupLabel:             "blue",
+				EnforceExclusiveHsmGroups: true,
+				PowerPolicy:               v1alpha3.PowerPolicyOff,
+			}},
+			TenantKmsResource: v1alpha3.TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-3072"},
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+
+	// The first pass waits for the PCS transition to complete.
+	deploy := func() {
+		for i := 0; i < 3 && (tenant.Status.Phase != v1alpha3.PhaseDeployed || tenant.Status.ObservedGeneration != tenant.Generation); i++ {
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tenant.Status.Phase != v1alpha3.PhaseDeployed {
+			t.Fatalf("expected the tenant to be deployed, got %+v", tenant.Status)
+		}
+	}
+	deploy()
+
+	for _, xname := range []string{"x0c3s5b0n0", "x0c3s6b0n0"} {
+		if state := pcsFake.PowerState(xname); state != "off" {
+			t.Errorf("expected %s to be powered off, got %s", xname, state)
+		}
+	}
+	if partition := hsmFake.Partition("blue"); partition == nil || len(partition.Members.Ids) != 2 {
+		t.Errorf("expected HSM partition blue with 2 members, got %+v", partition)
+	}
+	if group := hsmFake.Group("blue"); group == nil || len(group.Members.Ids) != 2 {
+		t.Errorf("expected HSM group blue with 2 members, got %+v", group)
+	}
+	if group := keycloakFake.Group("vcluster-blue-tenant-admin"); group == nil || len(group.Roles) != 1 {
+		t.Errorf("expected Keycloak group with the tenant-admin role, got %+v", group)
+	}
+	applied := tenant.Status.Applied
+	if applied == nil || !reflect.DeepEqual(applied.ChildNamespaces, []string{"vcluster-blue-slurm"}) ||
+		len(applied.HsmPartitions) != 1 || len(applied.HsmGroups) != 1 || len(applied.HsmGroups[0].Xnames) != 2 {
+		t.Errorf("expected the applied namespaces and HSM members in the status, got %+v", applied)
+	}
+	transit := tenant.Status.TenantKmsStatus.TransitName
+	if transit == "" || !vaultFake.Exists("sys/mounts/"+transit) || !vaultFake.Exists(transit+"/keys/key1") {
+		t.Errorf("expected Vault transit engine %q with key1, got mounts %v", transit, vaultFake.Mounts())
+	}
+
+	// Move one xname out of the tenant and another in.
+	tenant.Spec.TenantResources[0].Xnames = []string{"x0c3s6b0n0", "x0c3s7b0n0"}
+	tenant.Generation++
+	if err := r.Update(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	deploy()
+	for _, members := range [][]string{hsmFake.Partition("blue").Members.Ids, hsmFake.Group("blue").Members.Ids} {
+		sort.Strings(members)
+		if !reflect.DeepEqual(members, []string{"x0c3s6b0n0", "x0c3s7b0n0"}) {
+			t.Errorf("expected the HSM members to be updated, got %v", members)
+		}
+	}
+
+	if err := r.Delete(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, tenant); err == nil {
+		t.Errorf("expected the tenant to be deleted, got finalizers %v", tenant.Finalizers)
+	}
+	if hsmFake.Partition("blue") != nil || hsmFake.Group("blue") != nil {
+		t.Error("expected the HSM partition and group to be deleted")
+	}
+	if keycloakFake.Group("vcluster-blue-tenant-admin") != nil {
+		t.Error("expected the Keycloak group to be deleted")
+	}
+	if len(vaultFake.Mounts()) != 0 {
+		t.Errorf("expected the Vault transit engine to be deleted, got %v", vaultFake.Mounts())
+	}
+}
+
+func TestTenantRollback(t *testing.T) {
+	hsmFake, _, keycloakFake, vaultFake := startFakes(t)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// Vault rejects the key type, failing the last provisioning step.
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName:      "vcluster-blue",
+			ChildNamespaces: []string{"slurm"},
+			TenantResources: []v1alpha3.TenantResource{{
+				Type:             "compute",
+				Xnames:           []string{"x0c3s5b0n0"},
+				HsmPartitionName: "blue",
+				HsmGroupLabel:    "blue",
+			}},
+			TenantKmsResource: v1alpha3.TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-1024"},
+			FailurePolicy:     v1alpha3.FailurePolicyRollback,
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+			t.Fatal(err)
+		}
+		ready := tenant.GetCondition(v1alpha3.ConditionReady)
+		if tenant.Status.Phase != v1alpha3.PhaseFailed || ready == nil || ready.Reason != v1alpha3.ReasonRolledBack {
+			t.Fatalf("expected the tenant to be rolled back, got %+v", tenant.Status)
+		}
+		if len(tenant.Status.Journal) != 0 {
+			t.Errorf("expected an empty journal, got %+v", tenant.Status.Journal)
+		}
+		if hsmFake.Partition("blue") != nil || hsmFake.Group("blue") != nil {
+			t.Error("expected the HSM partition and group to be deleted")
+		}
+		if keycloakFake.Group("vcluster-blue-tenant-admin") != nil {
+			t.Error("expected the Keycloak group to be deleted")
+		}
+		if len(vaultFake.Mounts()) != 0 {
+			t.Errorf("expected the Vault transit engine to be deleted, got %v", vaultFake.Mounts())
+		}
+		anchors := &hncapi.SubnamespaceAnchorList{}
+		if err := r.List(ctx, anchors); err != nil || len(anchors.Items) != 0 {
+			t.Errorf("expected the namespace anchors to be deleted, got %d (%v)", len(anchors.Items), err)
+		}
+	}
+
+	// Fixing the spec provisions the tenant again.
+	tenant.Spec.TenantKmsResource.KeyType = "rsa-3072"
+	tenant.Generation++
+	if err := r.Update(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+		t.Fatal(err)
+	}
+	if tenant.Status.Phase != v1alpha3.PhaseDeployed || len(tenant.Status.Journal) != 0 {
+		t.Errorf("expected the tenant to be deployed with an empty journal, got %+v", tenant.Status)
+	}
+	if hsmFake.Partition("blue") == nil || len(vaultFake.Mounts()) != 1 {
+		t.Error("expected the HSM partition and Vault transit engine to be created again")
+	}
+}
+
+func TestTenantXnameSelectors(t *testing.T) {
+	hsmFake, _, _, _ := startFakes(t)
+	node := func(id string, role string, nid int32) hsm.Component {
+		return hsm.Component{ID: id, Type: "Node", Role: role, NID: nid}
+	}
+	hsmFake.AddComponents(
+		node("x1000c0s0b0n0", "Compute", 1),
+		node("x1000c0s0b0n1", "Compute", 2),
+		node("x1000c0s1b0n0", "Compute", 3),
+		node("x1000c1s0b0n0", "Compute", 4),
+		node("x1000c0s1b0n1", "Application", 6),
+	)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName: "vcluster-blue",
+			TenantResources: []v1alpha3.TenantResource{{
+				Type:             "compute",
+				Xnames:           []string{"x1000c1s0b0n0"},
+				XnamePatterns:    []string{"x1000c0s[0-2]b0n[0-1]"},
+				Selector:         &v1alpha3.TenantXnameSelector{NIDs: []string{"1-2,5-6"}},
+				HsmPartitionName: "blue",
+			}},
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+
+	reconcile := func(expected []string) {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+			t.Fatal(err)
+		}
+		resolved := []v1alpha3.TenantResolvedXnames{{Type: "compute", Xnames: expected[1:]}}
+		if !reflect.DeepEqual(tenant.Status.ResolvedXnames, resolved) {
+			t.Errorf("expected resolved xnames %+v, got %+v", resolved, tenant.Status.ResolvedXnames)
+		}
+		if tenant.Status.Phase != v1alpha3.PhaseDeployed || !reflect.DeepEqual(tenant.Status.TenantResources[0].Xnames, expected) {
+			t.Errorf("expected the tenant to be deployed with xnames %v, got %+v", expected, tenant.Status)
+		}
+		partition := hsmFake.Partition("blue")
+		if partition == nil {
+			t.Fatal("expected the HSM partition to be created")
+		}
+		members := append([]string(nil), partition.Members.Ids...)
+		sort.Strings(members)
+		sortedExpected := append([]string(nil), expected...)
+		sort.Strings(sortedExpected)
+		if !reflect.DeepEqual(members, sortedExpected) {
+			t.Errorf("expected partition members %v, got %v", sortedExpected, members)
+		}
+	}
+	reconcile([]string{"x1000c1s0b0n0", "x1000c0s0b0n0", "x1000c0s0b0n1"})
+
+	// A node added to HSM is picked up when the tenant is next reconciled.
+	hsmFake.AddComponents(node("x1000c0s2b0n0", "Compute", 5))
+	reconcile([]string{"x1000c1s0b0n0", "x1000c0s0b0n0", "x1000c0s0b0n1", "x1000c0s2b0n0"})
+}
+
+func TestTenantResolvedXnameClaimed(t *testing.T) {
+	hsmFake, _, _, _ := startFakes(t)
+	hsmFake.AddComponents(
+		hsm.Component{ID: "x1000c0s0b0n0", Type: "Node", Role: "Compute", NID: 1},
+		hsm.Component{ID: "x1000c0s0b0n1", Type: "Node", Role: "Compute", NID: 2},
+	)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	tenant := func(name string, pattern string) *v1alpha3.Tenant {
+		return &v1alpha3.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenants", Generation: 1},
+			Spec: v1alpha3.TenantSpec{
+				TenantName: name,
+				TenantResources: []v1alpha3.TenantResource{{
+					Type:             "compute",
+					XnamePatterns:    []string{pattern},
+					HsmPartitionName: name,
+				}},
+			},
+		}
+	}
+	r := &TenantReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			tenant("vcluster-blue", "x1000c0s0b0n0"),
+			tenant("vcluster-red", "x1000c0s0b0n[0-1]"),
+		).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+
+	reconcile := func(name string, fails bool) *v1alpha3.Tenant {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: name}}
+		if _, err := r.Reconcile(ctx, req); (err != nil) != fails {
+			t.Fatalf("expected reconcile of %s to fail: %v, got %v", name, fails, err)
+		}
+		reconciled := &v1alpha3.Tenant{}
+		if err := r.Get(ctx, req.NamespacedName, reconciled); err != nil {
+			t.Fatal(err)
+		}
+		return reconciled
+	}
+	if blue := reconcile("vcluster-blue", false); blue.Status.Phase != v1alpha3.PhaseDeployed {
+		t.Fatalf("expected vcluster-blue to be deployed, got %+v", blue.Status)
+	}
+
+	// The red tenant's pattern also matches the node claimed by the blue
+	// tenant, so it fails and the node is not added to its partition.
+	red := reconcile("vcluster-red", true)
+	condition := red.GetCondition(v1alpha3.ConditionXnamesReady)
+	if red.Status.Phase != v1alpha3.PhaseFailed || condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("expected vcluster-red to fail with XnamesReady false, got %+v", red.Status)
+	}
+	if v1alpha3.Contains(red.ClaimedXnames(), "x1000c0s0b0n0") {
+		t.Errorf("expected x1000c0s0b0n0 to be dropped from vcluster-red, got %+v", red.Status.ResolvedXnames)
+	}
+	if partition := hsmFake.Partition("vcluster-red"); partition != nil && v1alpha3.Contains(partition.Members.Ids, "x1000c0s0b0n0") {
+		t.Errorf("expected x1000c0s0b0n0 not to be added to partition vcluster-red, got %v", partition.Members.Ids)
+	}
+	if partition := hsmFake.Partition("vcluster-blue"); partition == nil || !reflect.DeepEqual(partition.Members.Ids, []string{"x1000c0s0b0n0"}) {
+		t.Errorf("expected vcluster-blue to keep x1000c0s0b0n0, got %+v", partition)
+	}
+}
+
+// lifecycleDeliveries returns the number of hook deliveries queued for a
+// lifecycle event.
+func lifecycleDeliveries(t *testing.T, c client.Client, event string) int {
+	deliveries := &v1alpha3.HookDeliveryList{}
+	if err := c.List(context.Background(), deliveries); err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for _, delivery := range deliveries.Items {
+		if delivery.Spec.EventType == event {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTenantProvisionedOnce(t *testing.T) {
+	hsmFake, pcsFake, keycloakFake, vaultFake := startFakes(t)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName:        "vcluster-blue",
+			TenantResources:   []v1alpha3.TenantResource{{Type: "compute", Xnames: []string{"x0c3s5b0n0"}, HsmPartitionName: "blue"}},
+			TenantKmsResource: v1alpha3.TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-3072"},
+			TenantHooks:       []v1alpha3.TenantHook{{Name: "wlm", Url: "http://wlm", EventTypes: []string{v1alpha3.EventProvisioned}}},
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+	reconcile := func(phase string) {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+			t.Fatal(err)
+		}
+		if tenant.Status.Phase != phase {
+			t.Fatalf("expected phase %s, got %+v", phase, tenant.Status)
+		}
+	}
+
+	reconcile(v1alpha3.PhaseDeployed)
+
+	// Vault is unreachable while the tenant is next checked for drift.
+	cfg := fakesConfig(hsmFake, pcsFake, keycloakFake, vaultFake)
+	cfg.Vault.URL = "http://127.0.0.1:1"
+	t.Setenv("VAULT_MAX_RETRIES", "0")
+	if err := v1alpha3.Configure(cfg); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(v1alpha3.PhaseDeploying)
+
+	if err := v1alpha3.Configure(fakesConfig(hsmFake, pcsFake, keycloakFake, vaultFake)); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(v1alpha3.PhaseDeployed)
+	reconcile(v1alpha3.PhaseDeployed)
+
+	if count := lifecycleDeliveries(t, r.Client, v1alpha3.EventProvisioned); count != 1 {
+		t.Errorf("expected one PROVISIONED delivery, got %d", count)
+	}
+	if tenant.Status.ProvisionedGeneration != tenant.Generation {
+		t.Errorf("expected PROVISIONED to be recorded for generation %d, got %d", tenant.Generation, tenant.Status.ProvisionedGeneration)
+	}
+}
+
+func TestTenantFailedOnce(t *testing.T) {
+	hsmFake, pcsFake, keycloakFake, vaultFake := startFakes(t)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// Vault rejects the key type once it is reachable.
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName:        "vcluster-blue",
+			TenantResources:   []v1alpha3.TenantResource{{Type: "compute", Xnames: []string{"x0c3s5b0n0"}, HsmPartitionName: "blue"}},
+			TenantKmsResource: v1alpha3.TenantKmsResource{Enabled: true, KeyName: "key1", KeyType: "rsa-1024"},
+			TenantHooks:       []v1alpha3.TenantHook{{Name: "wlm", Url: "http://wlm", EventTypes: []string{v1alpha3.EventFailed}}},
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+	// Permanent failures are returned as errors, so only the status is checked.
+	reconcile := func(phase string) {
+		r.Reconcile(ctx, req)
+		if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+			t.Fatal(err)
+		}
+		if tenant.Status.Phase != phase {
+			t.Fatalf("expected phase %s, got %+v", phase, tenant.Status)
+		}
+	}
+
+	cfg := fakesConfig(hsmFake, pcsFake, keycloakFake, vaultFake)
+	cfg.Vault.URL = "http://127.0.0.1:1"
+	t.Setenv("VAULT_MAX_RETRIES", "0")
+	if err := v1alpha3.Configure(cfg); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(v1alpha3.PhaseDeploying)
+	if count := lifecycleDeliveries(t, r.Client, v1alpha3.EventFailed); count != 0 {
+		t.Errorf("expected no FAILED delivery for a retryable failure, got %d", count)
+	}
+
+	if err := v1alpha3.Configure(fakesConfig(hsmFake, pcsFake, keycloakFake, vaultFake)); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(v1alpha3.PhaseFailed)
+	reconcile(v1alpha3.PhaseFailed)
+	if count := lifecycleDeliveries(t, r.Client, v1alpha3.EventFailed); count != 1 {
+		t.Errorf("expected one FAILED delivery, got %d", count)
+	}
+}
+
+func TestTenantRejectedMemberNotApplied(t *testing.T) {
+	hsmFake, _, _, _ := startFakes(t)
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := hncapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	// x0c3s6b0n0 is already in another tenant's partition.
+	red := hsm.Partition{Name: "red", Members: hsm.Ids{Ids: []string{"x0c3s6b0n0"}}}
+	if err := v1alpha3.HsmClient.CreatePartition(ctx, red); err != nil {
+		t.Fatal(err)
+	}
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "vcluster-blue", Namespace: "tenants", Generation: 1},
+		Spec: v1alpha3.TenantSpec{
+			TenantName:      "vcluster-blue",
+			TenantResources: []v1alpha3.TenantResource{{Type: "compute", Xnames: []string{"x0c3s5b0n0"}, HsmPartitionName: "blue"}},
+		},
+	}
+	r := &TenantReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build(),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	v1alpha3.HooksClient = r.Client
+	defer func() { v1alpha3.HooksClient = nil }()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "tenants", Name: "vcluster-blue"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	tenant.Spec.TenantResources[0].Xnames = []string{"x0c3s5b0n0", "x0c3s6b0n0"}
+	tenant.Generation++
+	if err := r.Update(ctx, tenant); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, req); err == nil {
+			t.Error("expected adding the xname in another partition to fail")
+		}
+		if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+			t.Fatal(err)
+		}
+		if tenant.Status.Phase != v1alpha3.PhaseFailed {
+			t.Errorf("expected the tenant to fail, got %+v", tenant.Status)
+		}
+		applied := tenant.Status.Applied
+		if applied == nil || len(applied.HsmPartitions) != 1 || !reflect.DeepEqual(applied.HsmPartitions[0].Xnames, []string{"x0c3s5b0n0"}) {
+			t.Errorf("expected only x0c3s5b0n0 to be applied, got %+v", applied)
+		}
+	}
+	if partition := hsmFake.Partition("blue"); partition == nil || !reflect.DeepEqual(partition.Members.Ids, []string{"x0c3s5b0n0"}) {
+		t.Errorf("expected partition blue to keep its members, got %+v", partition)
+	}
+}
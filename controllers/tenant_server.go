@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -28,12 +28,18 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/auth"
 	"github.com/gin-gonic/gin"
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -44,6 +50,9 @@ type TenantServer struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// Auth validates the bearer tokens sent to the tenant API. If nil,
+	// tokens are validated against the Keycloak shasta realm.
+	Auth *auth.Validator
 }
 
 type ResponseError struct {
@@ -61,6 +70,10 @@ func (r *TenantServer) SetupServerController(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+	if r.Auth == nil {
+		r.Auth = auth.NewValidator(v1alpha3.GetKeycloakCertsUrl(), v1alpha3.GetKeycloakIssuers(),
+			v1alpha3.GetKeycloakClientID(), v1alpha3.KeycloakHttpClient())
+	}
 	r.initRoutes()
 	return nil
 }
@@ -70,18 +83,109 @@ func (r *TenantServer) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 }
 
 func (r *TenantServer) initRoutes() {
+	go r.newRouter().Run(v1alpha3.GetServerPort())
+}
+
+func (r *TenantServer) newRouter() *gin.Engine {
 	router := gin.Default()
-	router.GET("v1alpha3/tenants", r.GetTenants)
-	router.GET("v1alpha3/tenants/:id", r.GetTenant)
-	router.POST("v1alpha3/tenants", r.GetTenantsByXname)
+	router.GET("v1alpha3/tenants", r.authenticate, r.GetTenants)
+	router.GET("v1alpha3/tenants/:id", r.authenticate, r.GetTenant)
+	router.POST("v1alpha3/tenants", r.authenticate, r.GetTenantsByXname)
+	router.POST("v1alpha3/tenants/:id", r.authenticate, r.requireAdmin, r.CreateTenant)
+	router.PUT("v1alpha3/tenants/:id", r.authenticate, r.requireAdmin, r.UpdateTenant)
+	router.PATCH("v1alpha3/tenants/:id", r.authenticate, r.requireAdmin, r.PatchTenant)
+	router.DELETE("v1alpha3/tenants/:id", r.authenticate, r.requireAdmin, r.DeleteTenant)
+	router.POST("v1alpha3/tenants/:id/plan", r.authenticate, r.requireAdmin, r.PlanTenant)
 	router.NoRoute(r.noRoute)
-	go router.Run(v1alpha3.GetServerPort())
+	return router
 }
 
 func (r *TenantServer) noRoute(c *gin.Context) {
 	c.JSON(404, gin.H{"message": "Page not found"})
 }
 
+// The gin context key for the claims of an authenticated request.
+const claimsKey = "claims"
+
+// authenticate rejects requests without a valid bearer token, and saves
+// the token's claims for canView and requireAdmin.
+func (r *TenantServer) authenticate(c *gin.Context) {
+	claims, err := r.Auth.ValidateHeader(c.Request.Context(), c.GetHeader("Authorization"))
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthorized) {
+			c.AbortWithStatusJSON(401, ResponseError{Message: fmt.Sprint(err)})
+			return
+		}
+		// The error is from fetching the signing keys, and isn't
+		// returned as it may describe the Keycloak deployment.
+		r.Log.Error(err, "Failed to validate token")
+		c.AbortWithStatusJSON(503, ResponseError{Message: "Unable to validate the token, please try again later."})
+		return
+	}
+	c.Set(claimsKey, claims)
+	c.Next()
+}
+
+// requireAdmin rejects authenticated requests from users without the
+// admin role.
+func (r *TenantServer) requireAdmin(c *gin.Context) {
+	if !claimsFor(c).IsAdmin() {
+		c.AbortWithStatusJSON(403, ResponseError{Message: "The admin role is required to manage tenants."})
+		return
+	}
+	c.Next()
+}
+
+func claimsFor(c *gin.Context) *auth.Claims {
+	if claims, ok := c.Get(claimsKey); ok {
+		return claims.(*auth.Claims)
+	}
+	return &auth.Claims{}
+}
+
+// canView returns true if the user may see the tenant: admins see every
+// tenant, and members of a tenant's Keycloak group see that tenant.
+func canView(c *gin.Context, tenant *v1alpha3.Tenant) bool {
+	claims := claimsFor(c)
+	return claims.IsAdmin() || claims.InGroup(v1alpha3.GetKeycloakGroupName(tenant.Spec.TenantName))
+}
+
+// visibleTenants returns the tenants in tenantList the user may see.
+func visibleTenants(c *gin.Context, tenantList *v1alpha3.TenantList) *v1alpha3.TenantList {
+	visible := v1alpha3.TenantList{TypeMeta: tenantList.TypeMeta, ListMeta: tenantList.ListMeta}
+	for i := range tenantList.Items {
+		if canView(c, &tenantList.Items[i]) {
+			visible.Items = append(visible.Items, tenantList.Items[i])
+		}
+	}
+	return &visible
+}
+
+// respondWithK8sError returns an error from the Kubernetes API (e.g. a
+// conflict, or a rejection by the admission webhook) with its status code.
+func (r *TenantServer) respondWithK8sError(c *gin.Context, err error) {
+	code := 500
+	if status, ok := err.(k8serrors.APIStatus); ok && status.Status().Code != 0 {
+		code = int(status.Status().Code)
+	}
+	c.JSON(code, ResponseError{Message: fmt.Sprint(err)})
+}
+
+// findTenant returns the tenant with the given name or UUID, or nil if
+// there is no such tenant.
+func (r *TenantServer) findTenant(c *gin.Context, id string) (*v1alpha3.Tenant, error) {
+	tenantList, err := r.GetTenantsFromCache(c)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenant := range tenantList.Items {
+		if tenant.Name == id || tenant.Status.UUID == id {
+			return tenant.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *TenantServer) GetTenantsFromCache(c *gin.Context) (*v1alpha3.TenantList, error) {
 	var tenantList v1alpha3.TenantList
 	ctx, cancel := context.WithCancel(context.Background())
@@ -106,35 +210,43 @@ func (r *TenantServer) GetTenantsFromCache(c *gin.Context) (*v1alpha3.TenantList
 //	@Tags		Tenant and Partition Management System
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Param		xnames	body		string	true	"Array of Xnames"	SchemaExample(["x1000c0s0b0n0", "x1000c0s0b1n0"])
 //	@Success	200		{array}		v1alpha3.Tenant
 //	@Failure	400		{object}	ResponseError
+//	@Failure	401		{object}	ResponseError
 //	@Failure	404		{object}	ResponseError
 //	@Failure	500		{object}	ResponseError
+//	@Failure	503		{object}	ResponseError
 //	@Router		/v1alpha3/tenants [post]
 func (r *TenantServer) GetTenantsByXname(c *gin.Context) {
-	var tenantList v1alpha3.TenantList
-	tenantCache, err := r.GetTenantsFromCache(c)
-	if err != nil {
-		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
-		return
-	}
-
 	var xnames []string
 	if err := c.BindJSON(&xnames); err != nil {
 		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
 		return
 	}
-	for _, tenant := range tenantCache.Items {
-		for _, resource := range tenant.Spec.TenantResources {
-			if v1alpha3.HasIntersection(xnames, resource.Xnames) {
-				tenantList.Items = append(tenantList.Items, tenant)
-				break
-			}
+
+	// Look up the tenants claiming each xname in the xname index, rather
+	// than scanning the resources of every tenant.
+	claiming := map[string]v1alpha3.Tenant{}
+	for _, xname := range xnames {
+		tenants, err := v1alpha3.ListTenantsByXname(c.Request.Context(), r.Client, xname, client.InNamespace("tenants"))
+		if err != nil {
+			c.JSON(500, ResponseError{Message: fmt.Sprintf("failed to get tenant list: %s", err)})
+			return
+		}
+		for _, tenant := range tenants {
+			claiming[tenant.Name] = tenant
 		}
 	}
 
-	c.JSON(200, tenantList)
+	var tenantList v1alpha3.TenantList
+	for _, tenant := range claiming {
+		tenantList.Items = append(tenantList.Items, tenant)
+	}
+	sort.Slice(tenantList.Items, func(i, j int) bool { return tenantList.Items[i].Name < tenantList.Items[j].Name })
+
+	c.JSON(200, visibleTenants(c, &tenantList))
 }
 
 // GetTenants
@@ -144,10 +256,13 @@ func (r *TenantServer) GetTenantsByXname(c *gin.Context) {
 //	@Tags		Tenant and Partition Management System
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Success	200	{array}		v1alpha3.Tenant
 //	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
 //	@Failure	404	{object}	ResponseError
 //	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
 //	@Router		/v1alpha3/tenants [get]
 func (r *TenantServer) GetTenants(c *gin.Context) {
 	tenantList, err := r.GetTenantsFromCache(c)
@@ -155,7 +270,7 @@ func (r *TenantServer) GetTenants(c *gin.Context) {
 		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
 		return
 	}
-	c.JSON(200, tenantList)
+	c.JSON(200, visibleTenants(c, tenantList))
 }
 
 // GetTenant
@@ -165,10 +280,13 @@ func (r *TenantServer) GetTenants(c *gin.Context) {
 //	@Tags		Tenant and Partition Management System
 //	@Accept		json
 //	@Produce	json
+//	@Security	BearerAuth
 //	@Success	200	{object}	v1alpha3.Tenant
 //	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
 //	@Failure	404	{object}	ResponseError
 //	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
 //	@Router		/v1alpha3/tenants/{id} [get]
 func (r *TenantServer) GetTenant(c *gin.Context) {
 	id := c.Param("id")
@@ -184,7 +302,7 @@ func (r *TenantServer) GetTenant(c *gin.Context) {
 	}
 
 	for _, tenant := range tenantList.Items {
-		if tenant.Name == id || tenant.Status.UUID == id {
+		if (tenant.Name == id || tenant.Status.UUID == id) && canView(c, &tenant) {
 			c.JSON(200, tenant)
 			return
 		}
@@ -192,3 +310,279 @@ func (r *TenantServer) GetTenant(c *gin.Context) {
 
 	c.JSON(404, fmt.Sprintf("Tenant with name/uuid '%s' not found.", id))
 }
+
+// CreateTenant
+//
+//	@Summary	Create a tenant
+//	@Param		id		path	string				true	"The Name of the Tenant"
+//	@Param		spec	body	v1alpha3.TenantSpec	true	"The desired state of the Tenant"
+//	@Tags		Tenant and Partition Management System
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	201	{object}	v1alpha3.Tenant
+//	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
+//	@Failure	403	{object}	ResponseError
+//	@Failure	409	{object}	ResponseError
+//	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
+//	@Router		/v1alpha3/tenants/{id} [post]
+func (r *TenantServer) CreateTenant(c *gin.Context) {
+	id := c.Param("id")
+	var spec v1alpha3.TenantSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	if spec.TenantName == "" {
+		spec.TenantName = id
+	} else if spec.TenantName != id {
+		c.JSON(400, ResponseError{Message: fmt.Sprintf("Tenant name '%s' does not match tenantname '%s'.", id, spec.TenantName)})
+		return
+	}
+
+	tenant := &v1alpha3.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      id,
+			Namespace: "tenants",
+		},
+		Spec: spec,
+	}
+	if err := tenant.ValidateSpec(); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+
+	if err := r.Create(c.Request.Context(), tenant); err != nil {
+		r.Log.Error(err, "Failed to create tenant "+id)
+		r.respondWithK8sError(c, err)
+		return
+	}
+	c.JSON(201, tenant)
+}
+
+// UpdateTenant
+//
+//	@Summary	Replace a tenant's spec
+//	@Param		id		path	string				true	"Either the Name or UUID of the Tenant"
+//	@Param		spec	body	v1alpha3.TenantSpec	true	"The desired state of the Tenant"
+//	@Tags		Tenant and Partition Management System
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200	{object}	v1alpha3.Tenant
+//	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
+//	@Failure	403	{object}	ResponseError
+//	@Failure	404	{object}	ResponseError
+//	@Failure	409	{object}	ResponseError
+//	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
+//	@Router		/v1alpha3/tenants/{id} [put]
+func (r *TenantServer) UpdateTenant(c *gin.Context) {
+	var spec v1alpha3.TenantSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	r.updateTenantSpec(c, spec)
+}
+
+// PatchTenant
+//
+//	@Summary	Update a tenant's spec with a JSON merge patch
+//	@Param		id		path	string	true	"Either the Name or UUID of the Tenant"
+//	@Param		patch	body	object	true	"A JSON merge patch (RFC 7386) of the Tenant spec"
+//	@Tags		Tenant and Partition Management System
+//	@Accept		json
+//	@Accept		application/merge-patch+json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200	{object}	v1alpha3.Tenant
+//	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
+//	@Failure	403	{object}	ResponseError
+//	@Failure	404	{object}	ResponseError
+//	@Failure	409	{object}	ResponseError
+//	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
+//	@Router		/v1alpha3/tenants/{id} [patch]
+func (r *TenantServer) PatchTenant(c *gin.Context) {
+	tenant, ok := r.tenantForWrite(c)
+	if !ok {
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	origSpec, err := json.Marshal(tenant.Spec)
+	if err != nil {
+		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	patchedSpec, err := jsonpatch.MergePatch(origSpec, patch)
+	if err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+
+	var spec v1alpha3.TenantSpec
+	if err := json.Unmarshal(patchedSpec, &spec); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	r.updateTenantSpec(c, spec)
+}
+
+// DeleteTenant
+//
+//	@Summary	Delete a tenant
+//	@Param		id	path	string	true	"Either the Name or UUID of the Tenant"
+//	@Tags		Tenant and Partition Management System
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	202	{object}	ResponseOk
+//	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
+//	@Failure	403	{object}	ResponseError
+//	@Failure	404	{object}	ResponseError
+//	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
+//	@Router		/v1alpha3/tenants/{id} [delete]
+func (r *TenantServer) DeleteTenant(c *gin.Context) {
+	tenant, ok := r.tenantForWrite(c)
+	if !ok {
+		return
+	}
+
+	if err := r.Delete(c.Request.Context(), tenant); err != nil {
+		r.Log.Error(err, "Failed to delete tenant "+tenant.Name)
+		r.respondWithK8sError(c, err)
+		return
+	}
+	c.JSON(202, ResponseOk{Message: fmt.Sprintf("Deletion of tenant '%s' requested.", tenant.Name)})
+}
+
+// PlanTenant
+//
+//	@Summary	Get the changes that applying a tenant spec would make, without making them
+//	@Param		id		path	string				true	"Either the Name or UUID of the Tenant"
+//	@Param		spec	body	v1alpha3.TenantSpec	true	"The desired state of the Tenant"
+//	@Tags		Tenant and Partition Management System
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200	{object}	v1alpha3.TenantPlan
+//	@Failure	400	{object}	ResponseError
+//	@Failure	401	{object}	ResponseError
+//	@Failure	403	{object}	ResponseError
+//	@Failure	500	{object}	ResponseError
+//	@Failure	503	{object}	ResponseError
+//	@Router		/v1alpha3/tenants/{id}/plan [post]
+func (r *TenantServer) PlanTenant(c *gin.Context) {
+	id := c.Param("id")
+	var spec v1alpha3.TenantSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+
+	tenant, err := r.findTenant(c, id)
+	if err != nil {
+		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	if tenant == nil {
+		tenant = &v1alpha3.Tenant{ObjectMeta: metav1.ObjectMeta{Name: id, Namespace: "tenants"}}
+	}
+	if spec.TenantName == "" {
+		spec.TenantName = tenant.Name
+	}
+	if tenant.Spec.TenantName != "" && spec.TenantName != tenant.Spec.TenantName {
+		c.JSON(400, ResponseError{Message: "The tenantname field is immutable."})
+		return
+	}
+	old := tenant.DeepCopy()
+	tenant.Spec = spec
+	if tenant.CreationTimestamp.IsZero() {
+		old = nil
+	}
+	if err := tenant.ValidateXnameSelectors(); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	if _, err := v1alpha3.ResolveXnames(c.Request.Context(), r.Log, tenant); err != nil {
+		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+
+	var globalHookList v1alpha3.GlobalTenantHookList
+	if err := r.List(c.Request.Context(), &globalHookList, client.InNamespace("tenants")); err != nil {
+		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+	var globalHooks []v1alpha3.TenantHook
+	for _, globalHook := range globalHookList.Items {
+		matches, err := globalHook.Spec.Matches(tenant, old)
+		if err != nil {
+			c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+			return
+		}
+		if matches {
+			globalHooks = append(globalHooks, globalHook.Spec.TenantHook)
+		}
+	}
+
+	c.JSON(200, v1alpha3.ComputeTenantPlan(tenant, globalHooks))
+}
+
+// tenantForWrite looks up the tenant named by the id path parameter,
+// responding with an error and returning false if it can't be found.
+func (r *TenantServer) tenantForWrite(c *gin.Context) (*v1alpha3.Tenant, bool) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(400, ResponseError{Message: "Tenant name or UUID must be provided."})
+		return nil, false
+	}
+	tenant, err := r.findTenant(c, id)
+	if err != nil {
+		c.JSON(500, ResponseError{Message: fmt.Sprint(err)})
+		return nil, false
+	}
+	if tenant == nil {
+		c.JSON(404, ResponseError{Message: fmt.Sprintf("Tenant with name/uuid '%s' not found.", id)})
+		return nil, false
+	}
+	return tenant, true
+}
+
+// updateTenantSpec validates and applies a new spec to the tenant named
+// by the id path parameter.
+func (r *TenantServer) updateTenantSpec(c *gin.Context, spec v1alpha3.TenantSpec) {
+	tenant, ok := r.tenantForWrite(c)
+	if !ok {
+		return
+	}
+	if spec.TenantName != tenant.Spec.TenantName {
+		c.JSON(400, ResponseError{Message: "The tenantname field is immutable."})
+		return
+	}
+
+	tenant.Spec = spec
+	if err := tenant.ValidateSpecUpdate(); err != nil {
+		c.JSON(400, ResponseError{Message: fmt.Sprint(err)})
+		return
+	}
+
+	if err := r.Update(c.Request.Context(), tenant); err != nil {
+		r.Log.Error(err, "Failed to update tenant "+tenant.Name)
+		r.respondWithK8sError(c, err)
+		return
+	}
+	c.JSON(200, tenant)
+}
@@ -0,0 +1,204 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/auth"
+)
+
+// The issuer of the tokens accepted by the test server.
+const testIssuer = "https://api-gw-service-nmn.local/keycloak/realms/shasta"
+
+// newTestServer returns a router for a TenantServer with the blue and red
+// tenants, and a function to sign tokens it accepts.
+func newTestServer(t *testing.T) (*gin.Engine, func(auth.Claims) string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "kid1", Algorithm: "RS256", Use: "sig"}}}
+	keycloak := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(keycloak.Close)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "kid1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(claims auth.Claims) string {
+		claims.Expiry = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		claims.Issuer = testIssuer
+		claims.AuthorizedParty = "shasta"
+		token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha3.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	var objs []runtime.Object
+	for _, name := range []string{"vcluster-blue", "vcluster-red"} {
+		objs = append(objs, &v1alpha3.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenants"},
+			Spec: v1alpha3.TenantSpec{
+				TenantName:      name,
+				TenantResources: []v1alpha3.TenantResource{{Type: "compute", Xnames: []string{name + "-xname"}}},
+			},
+		})
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := &TenantServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+		Log:    ctrl.Log,
+		Scheme: scheme,
+		Auth:   auth.NewValidator(keycloak.URL, []string{testIssuer}, "shasta", keycloak.Client()),
+	}
+	return r.newRouter(), sign
+}
+
+func request(router *gin.Engine, method string, path string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func tenantNames(t *testing.T, w *httptest.ResponseRecorder) []string {
+	var tenantList v1alpha3.TenantList
+	if err := json.Unmarshal(w.Body.Bytes(), &tenantList); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tenant := range tenantList.Items {
+		names = append(names, tenant.Name)
+	}
+	return names
+}
+
+func TestTenantReadAuthorization(t *testing.T) {
+	router, sign := newTestServer(t)
+	admin := sign(auth.Claims{RealmAccess: auth.Roles{Roles: []string{"admin"}}})
+	blueAdmin := sign(auth.Claims{Groups: []string{"/vcluster-blue-tenant-admin"}})
+
+	if w := request(router, http.MethodGet, "/v1alpha3/tenants", ""); w.Code != 401 {
+		t.Errorf("expected 401 without a token, got %d", w.Code)
+	}
+
+	w := request(router, http.MethodGet, "/v1alpha3/tenants", admin)
+	if w.Code != 200 || len(tenantNames(t, w)) != 2 {
+		t.Errorf("expected admin to see both tenants, got %d %s", w.Code, w.Body)
+	}
+
+	w = request(router, http.MethodGet, "/v1alpha3/tenants", blueAdmin)
+	if names := tenantNames(t, w); w.Code != 200 || len(names) != 1 || names[0] != "vcluster-blue" {
+		t.Errorf("expected tenant admin to see only their tenant, got %d %v", w.Code, names)
+	}
+
+	if w := request(router, http.MethodGet, "/v1alpha3/tenants/vcluster-blue", blueAdmin); w.Code != 200 {
+		t.Errorf("expected tenant admin to get their tenant, got %d", w.Code)
+	}
+	if w := request(router, http.MethodGet, "/v1alpha3/tenants/vcluster-red", blueAdmin); w.Code != 404 {
+		t.Errorf("expected 404 for another tenant, got %d", w.Code)
+	}
+	if w := request(router, http.MethodDelete, "/v1alpha3/tenants/vcluster-blue", blueAdmin); w.Code != 403 {
+		t.Errorf("expected 403 deleting as a tenant admin, got %d", w.Code)
+	}
+}
+
+func TestAuthenticateKeysUnavailable(t *testing.T) {
+	_, sign := newTestServer(t)
+	keycloak := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "keycloak-db connection refused", 500)
+	}))
+	t.Cleanup(keycloak.Close)
+	r := &TenantServer{
+		Log:  ctrl.Log,
+		Auth: auth.NewValidator(keycloak.URL, []string{testIssuer}, "shasta", keycloak.Client()),
+	}
+
+	w := request(r.newRouter(), http.MethodGet, "/v1alpha3/tenants", sign(auth.Claims{}))
+	if w.Code != 503 {
+		t.Errorf("expected 503 without signing keys, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "keycloak") {
+		t.Errorf("expected a generic error, got %s", w.Body)
+	}
+}
+
+func TestGetTenantsByXname(t *testing.T) {
+	router, sign := newTestServer(t)
+	admin := sign(auth.Claims{RealmAccess: auth.Roles{Roles: []string{"admin"}}})
+	blueAdmin := sign(auth.Claims{Groups: []string{"/vcluster-blue-tenant-admin"}})
+
+	byXname := func(token string, body string) []string {
+		req := httptest.NewRequest(http.MethodPost, "/v1alpha3/tenants", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != 200 {
+			t.Fatalf("expected 200, got %d %s", w.Code, w.Body)
+		}
+		return tenantNames(t, w)
+	}
+
+	if names := byXname(admin, `["vcluster-red-xname", "vcluster-blue-xname", "vcluster-red-xname"]`); !reflect.DeepEqual(names, []string{"vcluster-blue", "vcluster-red"}) {
+		t.Errorf("expected both tenants once each, got %v", names)
+	}
+	if names := byXname(admin, `["x9"]`); len(names) != 0 {
+		t.Errorf("expected no tenants for an unclaimed xname, got %v", names)
+	}
+	if names := byXname(blueAdmin, `["vcluster-red-xname", "vcluster-blue-xname"]`); !reflect.DeepEqual(names, []string{"vcluster-blue"}) {
+		t.Errorf("expected tenant admin to see only their tenant, got %v", names)
+	}
+}
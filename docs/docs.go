@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2023-2025 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2023-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -42,6 +42,11 @@ const docTemplate = `{
     "paths": {
         "/v1alpha3/tenants": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -68,6 +73,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ResponseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -79,10 +90,21 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ResponseError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
                     }
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -121,6 +143,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ResponseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -132,12 +160,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ResponseError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
                     }
                 }
             }
         },
         "/v1alpha3/tenants/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -170,23 +209,540 @@ const docTemplate = `{
                             "$ref": "#/definitions/ResponseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ResponseError"
-                        }
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant and Partition Management System"
+                ],
+                "summary": "Replace a tenant's spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Either the Name or UUID of the Tenant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The desired state of the Tenant",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TenantSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant and Partition Management System"
+                ],
+                "summary": "Create a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The Name of the Tenant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The desired state of the Tenant",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TenantSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant and Partition Management System"
+                ],
+                "summary": "Delete a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Either the Name or UUID of the Tenant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseOk"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant and Partition Management System"
+                ],
+                "summary": "Update a tenant's spec with a JSON merge patch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Either the Name or UUID of the Tenant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "A JSON merge patch (RFC 7386) of the Tenant spec",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1alpha3/tenants/{id}/plan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant and Partition Management System"
+                ],
+                "summary": "Get the changes that applying a tenant spec would make, without making them",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Either the Name or UUID of the Tenant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The desired state of the Tenant",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TenantSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TenantPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/ResponseError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "HookPlan": {
+            "description": "A hook TAPMS will call for a tenant change",
+            "type": "object",
+            "properties": {
+                "blockingcall": {
+                    "type": "boolean"
+                },
+                "eventtype": {
+                    "description": "The admission event, or PROVISIONED once the plan has been applied.",
+                    "type": "string",
+                    "example": "CREATE,UPDATE,PROVISIONED"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://\u003curl\u003e:\u003cport\u003e"
+                }
+            }
+        },
+        "HsmPlan": {
+            "description": "The changes TAPMS will make to an HSM partition or group",
+            "type": "object",
+            "properties": {
+                "addmembers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x0c3s5b0n0"
+                    ]
+                },
+                "create": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "The HSM partition name or group label.",
+                    "type": "string",
+                    "example": "blue"
+                },
+                "removemembers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x0c3s6b0n0"
+                    ]
+                }
+            }
+        },
+        "KeycloakPlan": {
+            "description": "The Keycloak group TAPMS will ensure exists for a tenant",
+            "type": "object",
+            "properties": {
+                "create": {
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string",
+                    "example": "vcluster-blue-tenant-admin"
+                }
+            }
+        },
+        "NamespacePlan": {
+            "description": "The namespaces TAPMS will create and delete for a tenant",
+            "type": "object",
+            "properties": {
+                "create": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vcluster-blue-slurm"
+                    ]
+                },
+                "delete": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vcluster-blue-user"
+                    ]
+                }
+            }
+        },
+        "PowerPlan": {
+            "description": "The xnames TAPMS will power off, if they are not already off",
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "type": "string",
+                    "example": "off"
+                },
+                "xnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ResponseError"
-                        }
-                    }
+                    "example": [
+                        "x0c3s5b0n0",
+                        "x0c3s6b0n0"
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
+        },
         "ResponseError": {
             "type": "object",
             "properties": {
@@ -196,6 +752,15 @@ const docTemplate = `{
                 }
             }
         },
+        "ResponseOk": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                }
+            }
+        },
         "Tenant": {
             "description": "The primary schema/definition of a tenant",
             "type": "object",
@@ -221,6 +786,36 @@ const docTemplate = `{
                 }
             }
         },
+        "TenantAppliedStatus": {
+            "description": "What TAPMS last applied to each backend for the tenant, updated as each step completes",
+            "type": "object",
+            "properties": {
+                "childnamespaces": {
+                    "description": "The child namespaces created for the tenant.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vcluster-blue-slurm"
+                    ]
+                },
+                "hsmgroups": {
+                    "description": "+listType=map\n+listMapKey=name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TenantHsmMembers"
+                    }
+                },
+                "hsmpartitions": {
+                    "description": "+listType=map\n+listMapKey=name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TenantHsmMembers"
+                    }
+                }
+            }
+        },
         "TenantHook": {
             "description": "The webhook definition to call an API for tenant CRUD operations",
             "type": "object",
@@ -230,6 +825,7 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "eventtypes": {
+                    "description": "CREATE, UPDATE and DELETE are sent on admission, and the lifecycle events\nPROVISIONED, DEPROVISIONED and FAILED once the tenant controller is done.",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -237,18 +833,75 @@ const docTemplate = `{
                     "example": [
                         "CREATE",
                         " UPDATE",
-                        " DELETE"
+                        " DELETE",
+                        " PROVISIONED",
+                        " DEPROVISIONED",
+                        " FAILED"
                     ]
                 },
                 "name": {
                     "type": "string"
                 },
+                "secretname": {
+                    "description": "+kubebuilder:validation:Optional\nThe name of a Secret, in the namespace of the tenant or global hook, with\ncredentials for calling the hook: hmac-key to sign payloads, token for a\nbearer token, tls.crt and tls.key for a client certificate, and ca.crt to\nverify the hook's server certificate.",
+                    "type": "string",
+                    "example": "vcluster-blue-hook"
+                },
                 "url": {
                     "type": "string",
                     "example": "http://\u003curl\u003e:\u003cport\u003e"
                 }
             }
         },
+        "TenantHsmMembers": {
+            "description": "The members of an HSM partition or group, as last applied by TAPMS",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "The HSM partition name or group label.",
+                    "type": "string",
+                    "example": "blue"
+                },
+                "xnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x0c3s5b0n0",
+                        "x0c3s6b0n0"
+                    ]
+                }
+            }
+        },
+        "TenantJournalEntry": {
+            "description": "A backend resource created by a provisioning step",
+            "type": "object",
+            "properties": {
+                "generation": {
+                    "description": "The generation of the tenant spec the resource was created for.",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "The kind of resource: Namespace, HsmPartition, HsmGroup, KeycloakGroup or VaultTransit.",
+                    "type": "string",
+                    "example": "HsmPartition"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "blue"
+                },
+                "step": {
+                    "description": "The provisioning step that created the resource.",
+                    "type": "string",
+                    "example": "HsmPartitionReady"
+                },
+                "time": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "TenantKmsResource": {
             "description": "The Vault KMS transit engine specification for the tenant",
             "type": "object",
@@ -289,12 +942,128 @@ const docTemplate = `{
                 }
             }
         },
+        "TenantPlan": {
+            "description": "The changes TAPMS will make to apply a tenant spec",
+            "type": "object",
+            "properties": {
+                "eventtype": {
+                    "type": "string",
+                    "example": "CREATE,UPDATE"
+                },
+                "hooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookPlan"
+                    }
+                },
+                "hsmgroups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HsmPlan"
+                    }
+                },
+                "hsmpartitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HsmPlan"
+                    }
+                },
+                "keycloak": {
+                    "$ref": "#/definitions/KeycloakPlan"
+                },
+                "namespaces": {
+                    "$ref": "#/definitions/NamespacePlan"
+                },
+                "poweroff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PowerPlan"
+                    }
+                },
+                "tenantname": {
+                    "type": "string",
+                    "example": "vcluster-blue"
+                },
+                "vault": {
+                    "description": "Only set if the tenant has KMS enabled.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/VaultPlan"
+                        }
+                    ]
+                }
+            }
+        },
+        "TenantPowerTransition": {
+            "description": "A power transition requested from PCS for xnames changing tenants",
+            "type": "object",
+            "properties": {
+                "failedxnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x0c3s6b0n0"
+                    ]
+                },
+                "observedgeneration": {
+                    "description": "The generation of the tenant spec that triggered the transition.",
+                    "type": "integer"
+                },
+                "operation": {
+                    "description": "The PCS operation, off or force-off.",
+                    "type": "string",
+                    "example": "off"
+                },
+                "status": {
+                    "description": "The PCS transition status, plus failed if any xname failed to power off.",
+                    "type": "string",
+                    "example": "new,in-progress,completed,aborted,failed"
+                },
+                "transitionid": {
+                    "description": "The PCS transition ID.",
+                    "type": "string",
+                    "example": "3d8e7f32-5c4a-4b3a-9a4e-2f1c0d6b7e11"
+                },
+                "xnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x0c3s5b0n0",
+                        "x0c3s6b0n0"
+                    ]
+                }
+            }
+        },
+        "TenantResolvedXnames": {
+            "description": "The xnames a tenant resource's patterns and selector resolved to",
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "The resource type.",
+                    "type": "string",
+                    "example": "compute"
+                },
+                "xnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x1000c0s0b0n0",
+                        "x1000c0s0b0n1"
+                    ]
+                }
+            }
+        },
         "TenantResource": {
             "description": "The desired resources for the Tenant",
             "type": "object",
             "required": [
-                "type",
-                "xnames"
+                "type"
             ],
             "properties": {
                 "enforceexclusivehsmgroups": {
@@ -308,11 +1077,35 @@ const docTemplate = `{
                     "type": "string",
                     "example": "blue"
                 },
+                "powerpolicy": {
+                    "description": "+kubebuilder:validation:Optional\n+kubebuilder:validation:Enum=off;force-off\nPower off xnames added to or removed from this resource, so they stop\nrunning the previous tenant's workload. Leave empty to not manage power.",
+                    "type": "string",
+                    "example": "off,force-off"
+                },
+                "selector": {
+                    "description": "+kubebuilder:validation:Optional\nSelect HSM nodes by their attributes. With patterns as well, nodes must\nmatch both a pattern and the selector. The nodes selected, plus any\nlisted in Xnames, are recorded in Status.ResolvedXnames.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/TenantXnameSelector"
+                        }
+                    ]
+                },
                 "type": {
                     "type": "string",
                     "example": "compute"
                 },
+                "xnamepatterns": {
+                    "description": "+kubebuilder:validation:Optional\nXname patterns with bracketed numeric ranges, e.g.\nx1000c[0-3]s[0-7]b[0-1]n[0-1]. Each matches the HSM nodes with the role\nof the resource type whose xname fits it.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x1000c[0-3]s[0-7]b[0-1]n[0-1]"
+                    ]
+                },
                 "xnames": {
+                    "description": "+kubebuilder:validation:Optional",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -341,8 +1134,18 @@ const docTemplate = `{
                         "vcluster-blue-slurm"
                     ]
                 },
+                "driftpolicy": {
+                    "description": "+kubebuilder:validation:Optional\n+kubebuilder:validation:Enum=report;enforce\n+kubebuilder:default:=report\nWhether changes made directly to the tenant's HSM partitions and groups\nare only reported, or are also reverted to match the spec.",
+                    "type": "string",
+                    "example": "report,enforce"
+                },
+                "failurepolicy": {
+                    "description": "+kubebuilder:validation:Optional\n+kubebuilder:validation:Enum=retry;rollback\n+kubebuilder:default:=retry\nWhether a tenant that fails to provision for the first time is retried,\nor the backend resources already created for it are deleted again.\nTransient backend failures are always retried.",
+                    "type": "string",
+                    "example": "retry,rollback"
+                },
                 "state": {
-                    "description": "+kubebuilder:validation:Optional",
+                    "description": "+kubebuilder:validation:Optional\nDeprecated: use Status.Phase, which is owned by the tenant controller.",
                     "type": "string",
                     "example": "New,Deploying,Deployed,Deleting"
                 },
@@ -378,7 +1181,16 @@ const docTemplate = `{
             "description": "The observed state of Tenant",
             "type": "object",
             "properties": {
+                "applied": {
+                    "description": "The namespaces and HSM members applied so far, which may be ahead of\nChildNamespaces and TenantResources while the tenant is deploying.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/TenantAppliedStatus"
+                        }
+                    ]
+                },
                 "childnamespaces": {
+                    "description": "The child namespaces, as of the last time the tenant was deployed.",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -387,6 +1199,51 @@ const docTemplate = `{
                         "vcluster-blue-slurm"
                     ]
                 },
+                "conditions": {
+                    "description": "The outcome of each provisioning step (NamespacesReady, HsmPartitionReady, etc).\n+listType=map\n+listMapKey=type",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "failedgeneration": {
+                    "description": "The generation of the tenant spec a FAILED lifecycle event was last\nqueued for.",
+                    "type": "integer"
+                },
+                "journal": {
+                    "description": "The backend resources created while provisioning the tenant, oldest\nfirst. Cleared once the tenant is deployed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TenantJournalEntry"
+                    }
+                },
+                "observedgeneration": {
+                    "description": "The most recent generation of the tenant spec acted on by the tenant controller.",
+                    "type": "integer"
+                },
+                "phase": {
+                    "description": "The lifecycle phase of the tenant, as last recorded by the tenant controller.",
+                    "type": "string",
+                    "example": "New,Deploying,Deployed,Deleting,Failed"
+                },
+                "powertransitions": {
+                    "description": "The most recent power transitions requested for xnames changing tenants.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TenantPowerTransition"
+                    }
+                },
+                "provisionedgeneration": {
+                    "description": "The generation of the tenant spec a PROVISIONED lifecycle event was\nlast queued for.",
+                    "type": "integer"
+                },
+                "resolvedxnames": {
+                    "description": "The xnames of each resource with xname patterns or a selector, as last\nresolved against HSM.\n+listType=map\n+listMapKey=type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TenantResolvedXnames"
+                    }
+                },
                 "tenanthooks": {
                     "type": "array",
                     "items": {
@@ -397,7 +1254,7 @@ const docTemplate = `{
                     "$ref": "#/definitions/TenantKmsStatus"
                 },
                 "tenantresources": {
-                    "description": "The desired resources for the Tenant",
+                    "description": "The resources for the Tenant, as of the last time it was deployed.",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/TenantResource"
@@ -409,6 +1266,67 @@ const docTemplate = `{
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
+        },
+        "TenantXnameSelector": {
+            "description": "HSM attributes selecting the nodes for a tenant resource. A node must match every attribute set.",
+            "type": "object",
+            "properties": {
+                "hsmgroup": {
+                    "description": "The label of an existing HSM group the nodes are members of.",
+                    "type": "string",
+                    "example": "rack-x1000"
+                },
+                "nids": {
+                    "description": "NIDs and NID ranges.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1-128",
+                        "256"
+                    ]
+                },
+                "role": {
+                    "description": "The HSM role of the nodes.",
+                    "type": "string",
+                    "example": "Compute"
+                },
+                "subrole": {
+                    "description": "The HSM subrole of the nodes.",
+                    "type": "string",
+                    "example": "UAN"
+                }
+            }
+        },
+        "VaultPlan": {
+            "description": "The Vault transit engine TAPMS will ensure exists for a tenant",
+            "type": "object",
+            "properties": {
+                "create": {
+                    "type": "boolean"
+                },
+                "keyname": {
+                    "type": "string",
+                    "example": "key1"
+                },
+                "keytype": {
+                    "type": "string",
+                    "example": "rsa-3072"
+                },
+                "transitname": {
+                    "type": "string",
+                    "example": "cray-tenant-550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "A Keycloak access token for the shasta realm, as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -420,7 +1338,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/apis/tapms/",
 	Schemes:          []string{},
 	Title:            "TAPMS Tenant Status API",
-	Description:      "Read-Only APIs to Retrieve Tenant Status",
+	Description:      "APIs to Retrieve Tenant Status and Manage Tenants",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
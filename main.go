@@ -2,7 +2,7 @@
  *
  *  MIT License
  *
- *  (C) Copyright 2022-2024 Hewlett Packard Enterprise Development LP
+ *  (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
  *
  *  Permission is hereby granted, free of charge, to any person obtaining a
  *  copy of this software and associated documentation files (the "Software"),
@@ -24,15 +24,20 @@
  *
  */
 
-//	@title			TAPMS Tenant Status API
-//	@version		v1alpha3
-//	@description	Read-Only APIs to Retrieve Tenant Status
-//	@host			cray-tapms
-//	@BasePath		/apis/tapms/
+//	@title						TAPMS Tenant Status API
+//	@version					v1alpha3
+//	@description				APIs to Retrieve Tenant Status and Manage Tenants
+//	@host						cray-tapms
+//	@BasePath					/apis/tapms/
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				A Keycloak access token for the shasta realm, as "Bearer <token>"
 
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -48,9 +53,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	v1alpha1 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha1"
 	v1alpha2 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha2"
 	v1alpha3 "github.com/Cray-HPE/cray-tapms-operator/api/v1alpha3"
 	"github.com/Cray-HPE/cray-tapms-operator/controllers"
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/operatorconfig"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -61,6 +68,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	utilruntime.Must(v1alpha2.AddToScheme(scheme))
 	utilruntime.Must(v1alpha3.AddToScheme(scheme))
 	utilruntime.Must(hncapi.AddToScheme(scheme))
@@ -73,10 +81,14 @@ func main() {
 	var probeAddr string
 	var namespace string
 	var webhookServerPort int
+	var operatorConfigFile string
 	flag.IntVar(&webhookServerPort, "webhook-server-port", 443, "The port that the webhook server serves at.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&namespace, "namespace", "tenants", "The namespace to watch for CRs in")
+	flag.StringVar(&operatorConfigFile, "operator-config", "",
+		"The operator config file with the backend service URLs, realms, credentials and TLS trust. "+
+			"Defaults are used if not set.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -88,6 +100,16 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	operatorConfig, err := operatorconfig.Load(operatorConfigFile)
+	if err != nil {
+		setupLog.Error(err, "unable to load operator config")
+		os.Exit(1)
+	}
+	if err = v1alpha3.Configure(operatorConfig); err != nil {
+		setupLog.Error(err, "unable to configure backend clients")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Namespace:              namespace,
@@ -102,15 +124,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = v1alpha3.IndexTenantXnames(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to index tenants by xname")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TenantReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Tenants"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Tenants"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("tenant-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tenants")
 		os.Exit(1)
 	}
 
+	if err = (&controllers.HookDeliveryReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("HookDeliveries"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("hookdelivery-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HookDeliveries")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TenantServer{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("Server"),
@@ -129,12 +167,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Registers the conversion webhook too, as every older Tenant version in
+	// the scheme converts through v1alpha3.
 	if err = (&v1alpha3.Tenant{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Tenant")
 		os.Exit(1)
 	}
+	if err = (&v1alpha3.GlobalTenantHook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "GlobalTenantHook")
+		os.Exit(1)
+	}
 
 	v1alpha3.HooksClient = mgr.GetClient()
+	v1alpha3.TenantIndexReader = mgr.GetClient()
+	v1alpha3.HookSecretsReader = mgr.GetAPIReader()
+
+	if err = mgr.Add(&v1alpha3.KeycloakSecretWatcher{Config: mgr.GetConfig()}); err != nil {
+		setupLog.Error(err, "unable to watch Keycloak secrets")
+		os.Exit(1)
+	}
+
+	if operatorConfigFile != "" {
+		if err = mgr.Add(&operatorconfig.Watcher{
+			Path:     operatorConfigFile,
+			Current:  operatorConfig,
+			Log:      ctrl.Log.WithName("config"),
+			OnChange: v1alpha3.Configure,
+		}); err != nil {
+			setupLog.Error(err, "unable to watch operator config")
+			os.Exit(1)
+		}
+	}
 
 	//+kubebuilder:scaffold:builder
 
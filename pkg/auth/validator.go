@@ -0,0 +1,220 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package auth validates Keycloak bearer tokens for the TAPMS tenant API.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// The Keycloak client whose roles are checked by IsAdmin.
+const shastaClient = "shasta"
+
+// The role granting full access to the tenant API.
+const adminRole = "admin"
+
+// The minimum time between JWKS refreshes triggered by an unknown key ID.
+const minRefreshInterval = 30 * time.Second
+
+// ErrUnauthorized is returned for a missing, malformed, expired or
+// unverifiable token, or one issued by another realm or for another client.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Roles are the roles granted to a token for a realm or client.
+type Roles struct {
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Claims are the Keycloak token claims used by TAPMS.
+type Claims struct {
+	jwt.Claims
+	PreferredUsername string           `json:"preferred_username,omitempty"`
+	Groups            []string         `json:"groups,omitempty"`
+	RealmAccess       Roles            `json:"realm_access,omitempty"`
+	ResourceAccess    map[string]Roles `json:"resource_access,omitempty"`
+	// The client the token was issued to.
+	AuthorizedParty string `json:"azp,omitempty"`
+}
+
+// IsAdmin returns true if the token has the admin role, either as a
+// realm role or as a role of the shasta client.
+func (c *Claims) IsAdmin() bool {
+	if contains(c.RealmAccess.Roles, adminRole) {
+		return true
+	}
+	return contains(c.ResourceAccess[shastaClient].Roles, adminRole)
+}
+
+// InGroup returns true if the token's groups claim includes group.
+// Keycloak may report groups by path (e.g. /vcluster-blue-tenant-admin).
+func (c *Claims) InGroup(group string) bool {
+	for _, g := range c.Groups {
+		if strings.TrimPrefix(g, "/") == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Validator validates tokens against the signing keys published by a
+// Keycloak realm.
+type Validator struct {
+	jwksUrl    string
+	issuers    []string
+	clientID   string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	keys      jose.JSONWebKeySet
+	refreshed time.Time
+}
+
+// NewValidator returns a Validator for the realm whose JWKS is served at
+// jwksUrl (e.g. http://keycloak/keycloak/realms/shasta/protocol/openid-connect/certs).
+// Tokens must be issued by one of issuers, the URLs the realm is reached
+// at, for clientID.
+func NewValidator(jwksUrl string, issuers []string, clientID string, httpClient *http.Client) *Validator {
+	return &Validator{
+		jwksUrl:    jwksUrl,
+		issuers:    issuers,
+		clientID:   clientID,
+		httpClient: httpClient,
+	}
+}
+
+// ValidateHeader validates the bearer token in an Authorization header.
+func (v *Validator) ValidateHeader(ctx context.Context, header string) (*Claims, error) {
+	token := strings.TrimPrefix(header, "Bearer ")
+	if len(header) == 0 || token == header {
+		return nil, fmt.Errorf("%w: missing bearer token", ErrUnauthorized)
+	}
+	return v.Validate(ctx, token)
+}
+
+// Validate checks the signature, expiry, issuer and audience of a raw
+// token, and returns its claims. A token is for the client if the client
+// is in its aud claim or is its azp claim.
+func (v *Validator) Validate(ctx context.Context, token string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if len(parsed.Headers) == 0 {
+		return nil, fmt.Errorf("%w: token has no header", ErrUnauthorized)
+	}
+
+	key, err := v.key(ctx, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := Claims{}
+	if err := parsed.Claims(key, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if err := claims.Validate(jwt.Expected{Time: time.Now()}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if !contains(v.issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrUnauthorized, claims.Issuer)
+	}
+	if !claims.Audience.Contains(v.clientID) && claims.AuthorizedParty != v.clientID {
+		return nil, fmt.Errorf("%w: token is not for client %q", ErrUnauthorized, v.clientID)
+	}
+	return &claims, nil
+}
+
+// key returns the signing key with the given ID, refreshing the cached
+// JWKS if the key is unknown (e.g. after Keycloak rotated its keys).
+func (v *Validator) key(ctx context.Context, keyID string) (*jose.JSONWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if keys := v.keys.Key(keyID); len(keys) > 0 {
+		return &keys[0], nil
+	}
+	if time.Since(v.refreshed) < minRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrUnauthorized, keyID)
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = *keys
+	v.refreshed = time.Now()
+
+	if keys := v.keys.Key(keyID); len(keys) > 0 {
+		return &keys[0], nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrUnauthorized, keyID)
+}
+
+func (v *Validator) fetchKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, fmt.Errorf("keycloak returned a non-200 response getting signing keys: %d", resp.StatusCode)
+	}
+
+	keys := jose.JSONWebKeySet{}
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, err
+	}
+	return &keys, nil
+}
+
+func contains(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
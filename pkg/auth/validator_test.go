@@ -0,0 +1,136 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func newTestValidator(t *testing.T) (*Validator, jose.Signer) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "kid1", Algorithm: "RS256", Use: "sig"}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(server.Close)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", "kid1").WithType("JWT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewValidator(server.URL, []string{testIssuer}, "shasta", server.Client()), signer
+}
+
+// The issuer of the test realm's tokens.
+const testIssuer = "https://api-gw-service-nmn.local/keycloak/realms/shasta"
+
+// validClaims returns claims the test validator accepts.
+func validClaims() Claims {
+	return Claims{
+		Claims: jwt.Claims{
+			Issuer:   testIssuer,
+			Audience: jwt.Audience{"account"},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		AuthorizedParty: "shasta",
+	}
+}
+
+func sign(t *testing.T, signer jose.Signer, claims Claims) string {
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestValidate(t *testing.T) {
+	v, signer := newTestValidator(t)
+	claims := validClaims()
+	claims.Groups = []string{"/vcluster-blue-tenant-admin"}
+	claims.ResourceAccess = map[string]Roles{"shasta": {Roles: []string{"admin"}}}
+	token := sign(t, signer, claims)
+
+	validated, err := v.ValidateHeader(context.Background(), "Bearer "+token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !validated.IsAdmin() || !validated.InGroup("vcluster-blue-tenant-admin") || validated.InGroup("vcluster-red-tenant-admin") {
+		t.Errorf("unexpected claims %+v", validated)
+	}
+
+	claims = validClaims()
+	claims.Audience = jwt.Audience{"shasta"}
+	claims.AuthorizedParty = ""
+	if _, err := v.Validate(context.Background(), sign(t, signer, claims)); err != nil {
+		t.Errorf("expected a token with the client as audience to be valid, got %v", err)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	v, signer := newTestValidator(t)
+	expired := validClaims()
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	otherIssuer := validClaims()
+	otherIssuer.Issuer = "https://api-gw-service-nmn.local/keycloak/realms/master"
+	noIssuer := validClaims()
+	noIssuer.Issuer = ""
+	otherClient := validClaims()
+	otherClient.AuthorizedParty = "admin-client"
+	noClient := validClaims()
+	noClient.AuthorizedParty = ""
+
+	for name, header := range map[string]string{
+		"missing":      "",
+		"not bearer":   "Basic dXNlcjpwYXNz",
+		"malformed":    "Bearer not-a-token",
+		"expired":      "Bearer " + sign(t, signer, expired),
+		"other issuer": "Bearer " + sign(t, signer, otherIssuer),
+		"no issuer":    "Bearer " + sign(t, signer, noIssuer),
+		"other client": "Bearer " + sign(t, signer, otherClient),
+		"no client":    "Bearer " + sign(t, signer, noClient),
+	} {
+		if _, err := v.ValidateHeader(context.Background(), header); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("%s: expected an unauthorized error, got %v", name, err)
+		}
+	}
+}
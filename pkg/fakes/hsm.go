@@ -0,0 +1,296 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package fakes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/hsm"
+)
+
+// Hsm is a fake HSM (smd) service. Like HSM, it rejects adding an xname
+// to a second partition or to a second group with the same exclusive
+// group label.
+type Hsm struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	groups     map[string]*hsm.Group
+	partitions map[string]*hsm.Partition
+	components []hsm.Component
+}
+
+// NewHsm starts a fake HSM service. Use URL as the HSM base URL and
+// Close it when done.
+func NewHsm() *Hsm {
+	f := &Hsm{
+		groups:     map[string]*hsm.Group{},
+		partitions: map[string]*hsm.Partition{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hsm/v2/groups", f.handleGroups)
+	mux.HandleFunc("/hsm/v2/groups/", f.handleGroups)
+	mux.HandleFunc("/hsm/v2/partitions", f.handlePartitions)
+	mux.HandleFunc("/hsm/v2/partitions/", f.handlePartitions)
+	mux.HandleFunc("/hsm/v2/State/Components", f.handleComponents)
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// AddComponents adds components returned by /State/Components.
+func (f *Hsm) AddComponents(components ...hsm.Component) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.components = append(f.components, components...)
+}
+
+// Group returns a copy of the group with the given label, or nil.
+func (f *Hsm) Group(label string) *hsm.Group {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if group, ok := f.groups[label]; ok {
+		copied := *group
+		copied.Members.Ids = append([]string(nil), group.Members.Ids...)
+		return &copied
+	}
+	return nil
+}
+
+// Partition returns a copy of the partition with the given name, or nil.
+func (f *Hsm) Partition(name string) *hsm.Partition {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if partition, ok := f.partitions[name]; ok {
+		copied := *partition
+		copied.Members.Ids = append([]string(nil), partition.Members.Ids...)
+		return &copied
+	}
+	return nil
+}
+
+func (f *Hsm) handleGroups(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	segments := pathSegments(r, "/hsm/v2/groups")
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		groups := []hsm.Group{}
+		for _, group := range f.groups {
+			groups = append(groups, *group)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Label < groups[j].Label })
+		writeJSON(w, http.StatusOK, groups)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var group hsm.Group
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil || group.Label == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := f.groups[group.Label]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		members := group.Members.Ids
+		group.Members.Ids = nil
+		for _, xname := range members {
+			if !f.exclusive(&group, xname) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			group.Members.Ids = append(group.Members.Ids, xname)
+		}
+		f.groups[group.Label] = &group
+		writeJSON(w, http.StatusCreated, []map[string]string{{"URI": "/hsm/v2/groups/" + group.Label}})
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		group, ok := f.groups[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, group)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, ok := f.groups[segments[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.groups, segments[0])
+		w.WriteHeader(http.StatusOK)
+	case len(segments) == 2 && segments[1] == "members" && r.Method == http.MethodPost:
+		group, ok := f.groups[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var member hsm.MemberId
+		if err := json.NewDecoder(r.Body).Decode(&member); err != nil || member.Id == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if contains(group.Members.Ids, member.Id) || !f.exclusive(group, member.Id) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		group.Members.Ids = append(group.Members.Ids, member.Id)
+		writeJSON(w, http.StatusCreated, []map[string]string{{"URI": r.URL.Path + "/" + member.Id}})
+	case len(segments) == 3 && segments[1] == "members" && r.Method == http.MethodDelete:
+		group, ok := f.groups[segments[0]]
+		if !ok || !contains(group.Members.Ids, segments[2]) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		group.Members.Ids = remove(group.Members.Ids, segments[2])
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// exclusive returns false if xname is already a member of another group
+// with the same exclusive group label as group.
+func (f *Hsm) exclusive(group *hsm.Group, xname string) bool {
+	if group.ExclusiveGroup == "" {
+		return true
+	}
+	for label, other := range f.groups {
+		if label != group.Label && other.ExclusiveGroup == group.ExclusiveGroup && contains(other.Members.Ids, xname) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Hsm) handlePartitions(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	segments := pathSegments(r, "/hsm/v2/partitions")
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		partitions := []hsm.Partition{}
+		for _, partition := range f.partitions {
+			partitions = append(partitions, *partition)
+		}
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i].Name < partitions[j].Name })
+		writeJSON(w, http.StatusOK, partitions)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var partition hsm.Partition
+		if err := json.NewDecoder(r.Body).Decode(&partition); err != nil || partition.Name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := f.partitions[partition.Name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		for _, xname := range partition.Members.Ids {
+			if f.partitionOf(xname) != "" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		f.partitions[partition.Name] = &partition
+		writeJSON(w, http.StatusCreated, []map[string]string{{"URI": "/hsm/v2/partitions/" + partition.Name}})
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		partition, ok := f.partitions[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, partition)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, ok := f.partitions[segments[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.partitions, segments[0])
+		w.WriteHeader(http.StatusOK)
+	case len(segments) == 2 && segments[1] == "members" && r.Method == http.MethodPost:
+		partition, ok := f.partitions[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var member hsm.MemberId
+		if err := json.NewDecoder(r.Body).Decode(&member); err != nil || member.Id == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if f.partitionOf(member.Id) != "" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		partition.Members.Ids = append(partition.Members.Ids, member.Id)
+		writeJSON(w, http.StatusCreated, []map[string]string{{"URI": r.URL.Path + "/" + member.Id}})
+	case len(segments) == 3 && segments[1] == "members" && r.Method == http.MethodDelete:
+		partition, ok := f.partitions[segments[0]]
+		if !ok || !contains(partition.Members.Ids, segments[2]) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		partition.Members.Ids = remove(partition.Members.Ids, segments[2])
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// partitionOf returns the partition xname is a member of, if any.
+func (f *Hsm) partitionOf(xname string) string {
+	for name, partition := range f.partitions {
+		if contains(partition.Members.Ids, xname) {
+			return name
+		}
+	}
+	return ""
+}
+
+func (f *Hsm) handleComponents(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	list := hsm.ComponentList{Components: []hsm.Component{}}
+	for _, component := range f.components {
+		if types, ok := query["type"]; ok && !contains(types, component.Type) {
+			continue
+		}
+		if roles, ok := query["role"]; ok && !contains(roles, component.Role) {
+			continue
+		}
+		list.Components = append(list.Components, component)
+	}
+	writeJSON(w, http.StatusOK, list)
+}
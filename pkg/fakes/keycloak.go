@@ -0,0 +1,199 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KeycloakGroup is a group in the fake Keycloak shasta realm.
+type KeycloakGroup struct {
+	Id    string   `json:"id"`
+	Name  string   `json:"name"`
+	Path  string   `json:"path"`
+	Roles []string `json:"-"`
+}
+
+type keycloakRole struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Keycloak is a fake Keycloak. It issues tokens for any realm and
+// supports the shasta realm group and role APIs used by tapms. The
+// tenant-admin realm role exists from the start.
+type Keycloak struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	nextId int
+	groups map[string]*KeycloakGroup
+	roles  []keycloakRole
+}
+
+// NewKeycloak starts a fake Keycloak. Use URL as the Keycloak base URL
+// and Close it when done.
+func NewKeycloak() *Keycloak {
+	f := &Keycloak{
+		groups: map[string]*KeycloakGroup{},
+		roles:  []keycloakRole{{Id: "role-1", Name: "tenant-admin"}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/", f.handleRealms)
+	mux.HandleFunc("/admin/realms/shasta/groups", f.handleGroups)
+	mux.HandleFunc("/admin/realms/shasta/groups/", f.handleGroups)
+	mux.HandleFunc("/admin/realms/shasta/roles", f.handleRoles)
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// Group returns a copy of the group with the given name, or nil.
+func (f *Keycloak) Group(name string) *KeycloakGroup {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, group := range f.groups {
+		if group.Name == name {
+			copied := *group
+			copied.Roles = append([]string(nil), group.Roles...)
+			return &copied
+		}
+	}
+	return nil
+}
+
+func (f *Keycloak) handleRealms(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":       "fake-access-token",
+			"expires_in":         300,
+			"refresh_token":      "fake-refresh-token",
+			"refresh_expires_in": 1800,
+		})
+	case strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/certs") && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": []interface{}{}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *Keycloak) handleGroups(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	segments := pathSegments(r, "/admin/realms/shasta/groups")
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		groups := []KeycloakGroup{}
+		for _, group := range f.groups {
+			groups = append(groups, *group)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+		writeJSON(w, http.StatusOK, groups)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var group KeycloakGroup
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil || group.Name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, existing := range f.groups {
+			if existing.Name == group.Name {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		f.nextId++
+		group.Id = fmt.Sprintf("group-%d", f.nextId)
+		group.Path = "/" + group.Name
+		f.groups[group.Id] = &group
+		w.Header().Set("Location", r.URL.Path+"/"+group.Id)
+		w.WriteHeader(http.StatusCreated)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, ok := f.groups[segments[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.groups, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 3 && segments[1] == "role-mappings" && segments[2] == "realm" && r.Method == http.MethodPost:
+		group, ok := f.groups[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var roles []keycloakRole
+		if err := json.NewDecoder(r.Body).Decode(&roles); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, role := range roles {
+			if !f.roleExists(role) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if !contains(group.Roles, role.Name) {
+				group.Roles = append(group.Roles, role.Name)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *Keycloak) roleExists(role keycloakRole) bool {
+	for _, existing := range f.roles {
+		if existing.Id == role.Id && existing.Name == role.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Keycloak) handleRoles(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	roles := []keycloakRole{}
+	for _, role := range f.roles {
+		if name == "" || strings.Contains(role.Name, name) {
+			roles = append(roles, role)
+		}
+	}
+	writeJSON(w, http.StatusOK, roles)
+}
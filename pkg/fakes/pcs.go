@@ -0,0 +1,158 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/pcs"
+)
+
+// Pcs is a fake PCS (power-control) service. Transitions complete as
+// soon as they are created; xnames passed to FailXnames fail.
+type Pcs struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	powerStates map[string]string
+	failing     map[string]bool
+	transitions map[string]*pcs.Transition
+}
+
+// NewPcs starts a fake PCS service. Use URL as the PCS base URL and
+// Close it when done. Xnames are on until powered off.
+func NewPcs() *Pcs {
+	f := &Pcs{
+		powerStates: map[string]string{},
+		failing:     map[string]bool{},
+		transitions: map[string]*pcs.Transition{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/power-status", f.handlePowerStatus)
+	mux.HandleFunc("/v1/transitions", f.handleTransitions)
+	mux.HandleFunc("/v1/transitions/", f.handleTransitions)
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// FailXnames makes the tasks for the given xnames fail in later
+// transitions.
+func (f *Pcs) FailXnames(xnames ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, xname := range xnames {
+		f.failing[xname] = true
+	}
+}
+
+// PowerState returns the power state of xname.
+func (f *Pcs) PowerState(xname string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.powerState(xname)
+}
+
+// Transitions returns the number of transitions created.
+func (f *Pcs) Transitions() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.transitions)
+}
+
+func (f *Pcs) powerState(xname string) string {
+	if state, ok := f.powerStates[xname]; ok {
+		return state
+	}
+	return "on"
+}
+
+func (f *Pcs) handlePowerStatus(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	status := pcs.PowerStatus{}
+	for _, xname := range r.URL.Query()["xname"] {
+		status.Status = append(status.Status, pcs.XnamePowerState{
+			Xname:           xname,
+			PowerState:      f.powerState(xname),
+			ManagementState: "available",
+		})
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (f *Pcs) handleTransitions(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	segments := pathSegments(r, "/v1/transitions")
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var request pcs.TransitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Operation == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		transition := &pcs.Transition{
+			TransitionID:     fmt.Sprintf("transition-%d", len(f.transitions)+1),
+			Operation:        request.Operation,
+			TransitionStatus: pcs.TransitionStatusCompleted,
+		}
+		for _, location := range request.Location {
+			task := pcs.TransitionTask{Xname: location.Xname, TaskStatus: pcs.TaskStatusSucceeded}
+			if f.failing[location.Xname] {
+				task.TaskStatus = pcs.TaskStatusFailed
+				task.Error = "failed to power off"
+				transition.TaskCounts.Failed++
+			} else {
+				f.powerStates[location.Xname] = "off"
+				transition.TaskCounts.Succeeded++
+			}
+			transition.TaskCounts.Total++
+			transition.Tasks = append(transition.Tasks, task)
+		}
+		f.transitions[transition.TransitionID] = transition
+		writeJSON(w, http.StatusOK, pcs.TransitionStartOutput{TransitionID: transition.TransitionID, Operation: transition.Operation})
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		transition, ok := f.transitions[segments[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, transition)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Vault is a fake Vault storing whatever is written to it. Reading
+// sys/mounts/<mount> fails like Vault does for a missing mount, writing
+// <mount>/keys/<name> adds the key versions (or fails for an unknown key
+// type), and deleting a mount deletes everything under it. Any token is
+// accepted.
+type Vault struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+// NewVault starts a fake Vault. Use URL as the Vault address and Close
+// it when done.
+func NewVault() *Vault {
+	f := &Vault{data: map[string]map[string]interface{}{}}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// Exists returns true if something was written to path.
+func (f *Vault) Exists(path string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.data[path]
+	return ok
+}
+
+// Mounts returns the names of the mounted secret engines.
+func (f *Vault) Mounts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var mounts []string
+	for path := range f.data {
+		if strings.HasPrefix(path, "sys/mounts/") {
+			mounts = append(mounts, strings.TrimPrefix(path, "sys/mounts/"))
+		}
+	}
+	return mounts
+}
+
+// transitKeyTypes are the key types the transit engine supports.
+var transitKeyTypes = []string{
+	"aes128-gcm96", "aes256-gcm96", "chacha20-poly1305", "ed25519",
+	"ecdsa-p256", "ecdsa-p384", "ecdsa-p521", "rsa-2048", "rsa-3072", "rsa-4096",
+}
+
+func (f *Vault) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	if path == "auth/kubernetes/login" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": "fake-vault-token", "renewable": false},
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := f.data[path]
+		if !ok {
+			if mount := strings.TrimPrefix(path, "sys/mounts/"); mount != path {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+					"errors": []string{fmt.Sprintf("No secret engine mount at %s/", mount)},
+				})
+				return
+			}
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+	case http.MethodPost, http.MethodPut:
+		data := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{err.Error()}})
+			return
+		}
+		if mount := strings.SplitN(path, "/", 2)[0]; mount != "sys" && mount != "auth" {
+			if _, ok := f.data["sys/mounts/"+mount]; !ok {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{
+					"errors": []string{fmt.Sprintf("no handler for route %q", path)},
+				})
+				return
+			}
+			if strings.Contains(path, "/keys/") {
+				if keyType, _ := data["type"].(string); !contains(transitKeyTypes, keyType) {
+					writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+						"errors": []string{fmt.Sprintf("unknown key type: %s", keyType)},
+					})
+					return
+				}
+				data["keys"] = map[string]interface{}{"1": 1}
+			}
+		}
+		f.data[path] = data
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(f.data, path)
+		if mount := strings.TrimPrefix(path, "sys/mounts/"); mount != path {
+			for stored := range f.data {
+				if strings.HasPrefix(stored, mount+"/") {
+					delete(f.data, stored)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package hsm is a client for the Hardware State Manager (smd) APIs
+// used by TAPMS to manage tenant partitions and groups.
+package hsm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
+)
+
+// Client is the set of HSM operations used by TAPMS.
+type Client interface {
+	ListGroups(ctx context.Context) ([]Group, error)
+	GetGroup(ctx context.Context, label string) (*Group, error)
+	CreateGroup(ctx context.Context, group Group) error
+	DeleteGroup(ctx context.Context, label string) error
+	AddGroupMember(ctx context.Context, label string, xname string) error
+	RemoveGroupMember(ctx context.Context, label string, xname string) error
+
+	ListPartitions(ctx context.Context) ([]Partition, error)
+	GetPartition(ctx context.Context, name string) (*Partition, error)
+	CreatePartition(ctx context.Context, partition Partition) error
+	DeletePartition(ctx context.Context, name string) error
+	AddPartitionMember(ctx context.Context, name string, xname string) error
+	RemovePartitionMember(ctx context.Context, name string, xname string) error
+
+	// ListComponents lists the components with the given type and role. An
+	// empty type or role matches any.
+	ListComponents(ctx context.Context, nodeType string, role string) (*ComponentList, error)
+}
+
+// TokenSource returns a bearer token to use for a single HSM request.
+type TokenSource func(ctx context.Context) (string, error)
+
+type client struct {
+	baseUrl    string
+	httpClient *http.Client
+	token      TokenSource
+}
+
+// NewClient returns a Client for the smd service at baseUrl
+// (e.g. https://api-gw-service-nmn.local/apis/smd). The same
+// httpClient is used for every request.
+func NewClient(baseUrl string, httpClient *http.Client, token TokenSource) Client {
+	return &client{
+		baseUrl:    baseUrl,
+		httpClient: httpClient,
+		token:      token,
+	}
+}
+
+func (c *client) ListGroups(ctx context.Context) ([]Group, error) {
+	var groups []Group
+	err := c.do(ctx, http.MethodGet, "/hsm/v2/groups", nil, &groups, "listing groups")
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (c *client) GetGroup(ctx context.Context, label string) (*Group, error) {
+	var group Group
+	path := fmt.Sprintf("/hsm/v2/groups/%s", url.PathEscape(label))
+	err := c.do(ctx, http.MethodGet, path, nil, &group, "getting group")
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (c *client) CreateGroup(ctx context.Context, group Group) error {
+	return c.do(ctx, http.MethodPost, "/hsm/v2/groups", group, nil, "creating group")
+}
+
+func (c *client) DeleteGroup(ctx context.Context, label string) error {
+	path := fmt.Sprintf("/hsm/v2/groups/%s", url.PathEscape(label))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, "deleting group")
+}
+
+func (c *client) AddGroupMember(ctx context.Context, label string, xname string) error {
+	path := fmt.Sprintf("/hsm/v2/groups/%s/members", url.PathEscape(label))
+	// A repeated add fails with 409, which EditMembers checks against
+	// the members, so the request is safe to retry.
+	return c.do(retry.WithIdempotent(ctx), http.MethodPost, path, MemberId{Id: xname}, nil, fmt.Sprintf("adding member %s for group %s", xname, label))
+}
+
+func (c *client) RemoveGroupMember(ctx context.Context, label string, xname string) error {
+	path := fmt.Sprintf("/hsm/v2/groups/%s/members/%s", url.PathEscape(label), url.PathEscape(xname))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, fmt.Sprintf("removing member %s for group %s", xname, label))
+}
+
+func (c *client) ListPartitions(ctx context.Context) ([]Partition, error) {
+	var partitions []Partition
+	err := c.do(ctx, http.MethodGet, "/hsm/v2/partitions", nil, &partitions, "listing partitions")
+	if err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
+
+func (c *client) GetPartition(ctx context.Context, name string) (*Partition, error) {
+	var partition Partition
+	path := fmt.Sprintf("/hsm/v2/partitions/%s", url.PathEscape(name))
+	err := c.do(ctx, http.MethodGet, path, nil, &partition, "getting partition")
+	if err != nil {
+		return nil, err
+	}
+	return &partition, nil
+}
+
+func (c *client) CreatePartition(ctx context.Context, partition Partition) error {
+	return c.do(ctx, http.MethodPost, "/hsm/v2/partitions", partition, nil, "creating partition")
+}
+
+func (c *client) DeletePartition(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/hsm/v2/partitions/%s", url.PathEscape(name))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, "deleting partition")
+}
+
+func (c *client) AddPartitionMember(ctx context.Context, name string, xname string) error {
+	path := fmt.Sprintf("/hsm/v2/partitions/%s/members", url.PathEscape(name))
+	// A repeated add fails with 409, which EditMembers checks against
+	// the members, so the request is safe to retry.
+	return c.do(retry.WithIdempotent(ctx), http.MethodPost, path, MemberId{Id: xname}, nil, fmt.Sprintf("adding member %s for partition %s", xname, name))
+}
+
+func (c *client) RemovePartitionMember(ctx context.Context, name string, xname string) error {
+	path := fmt.Sprintf("/hsm/v2/partitions/%s/members/%s", url.PathEscape(name), url.PathEscape(xname))
+	return c.do(ctx, http.MethodDelete, path, nil, nil, fmt.Sprintf("removing member %s for partition %s", xname, name))
+}
+
+func (c *client) ListComponents(ctx context.Context, nodeType string, role string) (*ComponentList, error) {
+	query := url.Values{}
+	if nodeType != "" {
+		query.Set("type", nodeType)
+	}
+	if role != "" {
+		query.Set("role", role)
+	}
+	componentList := ComponentList{}
+	err := c.do(ctx, http.MethodGet, "/hsm/v2/State/Components?"+query.Encode(), nil, &componentList, "listing components")
+	if err != nil {
+		return nil, err
+	}
+	return &componentList, nil
+}
+
+// do issues a single request against HSM. The payload (if any) is sent
+// as JSON, and a 2xx response body is decoded into out (if not nil).
+// Any other response is returned as an *Error.
+func (c *client) do(ctx context.Context, method string, path string, payload interface{}, out interface{}, op string) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		return &Error{Op: op, StatusCode: resp.StatusCode}
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,107 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package hsm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, server.Client(), func(ctx context.Context) (string, error) {
+		return "token", nil
+	})
+}
+
+func TestListGroups(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/hsm/v2/groups" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		json.NewEncoder(w).Encode([]Group{{Label: "blue", Members: Ids{Ids: []string{"x0c3s5b0n0"}}}})
+	})
+
+	groups, err := c.ListGroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].Label != "blue" || groups[0].Members.Ids[0] != "x0c3s5b0n0" {
+		t.Errorf("unexpected groups %+v", groups)
+	}
+}
+
+func TestAddGroupMember(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/hsm/v2/groups/blue/members" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var member MemberId
+		json.NewDecoder(r.Body).Decode(&member)
+		if member.Id != "x0c3s5b0n0" {
+			t.Errorf("unexpected member %+v", member)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := c.AddGroupMember(context.Background(), "blue", "x0c3s5b0n0")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code        int
+		notFound    bool
+		conflict    bool
+		serverError bool
+	}{
+		{code: http.StatusNotFound, notFound: true},
+		{code: http.StatusConflict, conflict: true},
+		{code: http.StatusServiceUnavailable, serverError: true},
+		{code: http.StatusBadRequest},
+	} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.code)
+		})
+		err := c.RemovePartitionMember(context.Background(), "blue", "x0c3s5b0n0")
+		if err == nil {
+			t.Fatalf("expected error for status %d", tc.code)
+		}
+		if IsNotFound(err) != tc.notFound || IsConflict(err) != tc.conflict || IsServerError(err) != tc.serverError {
+			t.Errorf("unexpected classification for status %d: %v", tc.code, err)
+		}
+	}
+}
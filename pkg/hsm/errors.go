@@ -0,0 +1,75 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package hsm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
+)
+
+// Error is returned for any non-2xx response from HSM.
+type Error struct {
+	// Op describes the operation that failed, e.g. "listing groups".
+	Op string
+	// StatusCode is the HTTP status code returned by HSM.
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("HSM returned a non-200 response %s: %d", e.Op, e.StatusCode)
+}
+
+// Retryable returns true if the HSM response indicates a transient failure.
+func (e *Error) Retryable() bool {
+	return retry.RetryableStatus(e.StatusCode)
+}
+
+// IsNotFound returns true if err is an HSM 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, func(code int) bool { return code == http.StatusNotFound })
+}
+
+// IsConflict returns true if err is an HSM 409 response.
+func IsConflict(err error) bool {
+	return hasStatus(err, func(code int) bool { return code == http.StatusConflict })
+}
+
+// IsServerError returns true if err is an HSM 5xx response.
+func IsServerError(err error) bool {
+	return hasStatus(err, func(code int) bool { return code >= 500 && code <= 599 })
+}
+
+func hasStatus(err error, match func(int) bool) bool {
+	var hsmErr *Error
+	if errors.As(err, &hsmErr) {
+		return match(hsmErr.StatusCode)
+	}
+	return false
+}
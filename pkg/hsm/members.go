@@ -0,0 +1,198 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package hsm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The member edits made by EditMembers.
+const (
+	MemberAdd    = "add"
+	MemberRemove = "remove"
+)
+
+// The outcome of adding or removing a single group or partition member.
+const (
+	MemberChanged = "changed"
+	// MemberUnchanged means the xname was already added or removed.
+	MemberUnchanged = "unchanged"
+	MemberFailed    = "failed"
+)
+
+// MemberResult is the outcome of adding or removing one xname.
+type MemberResult struct {
+	Xname  string
+	Status string
+	Err    error
+}
+
+// MemberReport has a result for each xname, in the order they were given.
+type MemberReport []MemberResult
+
+// Count returns the number of xnames with the given status.
+func (r MemberReport) Count(status string) int {
+	count := 0
+	for _, result := range r {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the xnames that could not be added or removed.
+func (r MemberReport) Failed() []string {
+	var failed []string
+	for _, result := range r {
+		if result.Status == MemberFailed {
+			failed = append(failed, result.Xname)
+		}
+	}
+	return failed
+}
+
+// Applied returns the xnames that were added or removed, including those
+// that HSM reported already were. Failed xnames, and results with any
+// other status, are left out.
+func (r MemberReport) Applied() []string {
+	var applied []string
+	for _, result := range r {
+		if result.Status == MemberChanged || result.Status == MemberUnchanged {
+			applied = append(applied, result.Xname)
+		}
+	}
+	return applied
+}
+
+// Err returns a *MembersError if any xname failed, otherwise nil.
+func (r MemberReport) Err() error {
+	for _, result := range r {
+		if result.Status == MemberFailed {
+			return &MembersError{Failed: r.Failed(), Total: len(r), Err: result.Err}
+		}
+	}
+	return nil
+}
+
+// MembersError is returned when some members could not be added or
+// removed. It wraps the error of the first failed xname.
+type MembersError struct {
+	Failed []string
+	Total  int
+	Err    error
+}
+
+func (e *MembersError) Error() string {
+	return fmt.Sprintf("failed to update %d of %d members (%s): %v", len(e.Failed), e.Total, strings.Join(e.Failed, ","), e.Err)
+}
+
+func (e *MembersError) Unwrap() error {
+	return e.Err
+}
+
+// EditMembers calls edit, e.g. AddGroupMember, for each xname with at
+// most parallelism calls in flight, so large groups and partitions are
+// updated without one request at a time. op is MemberAdd or MemberRemove.
+// A 404 response to a remove means the xname was already removed. A 409
+// response to an add means the xname was already added only if it is in
+// the list returned by members, which is called at most once; otherwise,
+// e.g. for an xname in another partition or exclusive group, the add
+// failed. Every xname is attempted even if some fail.
+func EditMembers(ctx context.Context, op string, xnames []string, parallelism int, edit func(ctx context.Context, xname string) error, members func(ctx context.Context) ([]string, error)) MemberReport {
+	report := make(MemberReport, len(xnames))
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	current := &currentMembers{list: members}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism && worker < len(xnames); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				report[i] = editMember(ctx, op, xnames[i], edit, current)
+			}
+		}()
+	}
+	for i := range xnames {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return report
+}
+
+func editMember(ctx context.Context, op string, xname string, edit func(ctx context.Context, xname string) error, current *currentMembers) MemberResult {
+	if err := ctx.Err(); err != nil {
+		return MemberResult{Xname: xname, Status: MemberFailed, Err: err}
+	}
+	err := edit(ctx, xname)
+	switch {
+	case err == nil:
+		return MemberResult{Xname: xname, Status: MemberChanged}
+	case op == MemberRemove && IsNotFound(err):
+		return MemberResult{Xname: xname, Status: MemberUnchanged}
+	case op == MemberAdd && IsConflict(err):
+		if member, listErr := current.contains(ctx, xname); listErr != nil {
+			return MemberResult{Xname: xname, Status: MemberFailed, Err: listErr}
+		} else if member {
+			return MemberResult{Xname: xname, Status: MemberUnchanged}
+		}
+	}
+	return MemberResult{Xname: xname, Status: MemberFailed, Err: err}
+}
+
+// currentMembers lists the members of a group or partition the first
+// time they are needed.
+type currentMembers struct {
+	list    func(ctx context.Context) ([]string, error)
+	once    sync.Once
+	members map[string]bool
+	err     error
+}
+
+func (c *currentMembers) contains(ctx context.Context, xname string) (bool, error) {
+	if c.list == nil {
+		return false, nil
+	}
+	c.once.Do(func() {
+		var members []string
+		members, c.err = c.list(ctx)
+		c.members = map[string]bool{}
+		for _, member := range members {
+			c.members[member] = true
+		}
+	})
+	return c.members[xname], c.err
+}
@@ -0,0 +1,124 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package hsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEditMembers(t *testing.T) {
+	var xnames []string
+	for i := 0; i < 50; i++ {
+		xnames = append(xnames, fmt.Sprintf("x0c0s%db0n0", i))
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	members := func(ctx context.Context) ([]string, error) {
+		return []string{"x0c0s1b0n0"}, nil
+	}
+	report := EditMembers(context.Background(), MemberAdd, xnames, 4, func(ctx context.Context, xname string) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		switch xname {
+		case "x0c0s1b0n0":
+			return &Error{Op: "adding member", StatusCode: http.StatusConflict}
+		case "x0c0s2b0n0", "x0c0s3b0n0":
+			return &Error{Op: "adding member", StatusCode: http.StatusServiceUnavailable}
+		}
+		return nil
+	}, members)
+
+	if maxInFlight < 2 || maxInFlight > 4 {
+		t.Errorf("expected up to 4 requests in flight, got %d", maxInFlight)
+	}
+	if len(report) != len(xnames) || report[10].Xname != xnames[10] {
+		t.Fatalf("expected a result for each xname in order, got %+v", report)
+	}
+	if report.Count(MemberChanged) != 47 || report.Count(MemberUnchanged) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if !reflect.DeepEqual(report.Failed(), []string{"x0c0s2b0n0", "x0c0s3b0n0"}) {
+		t.Errorf("unexpected failed xnames %v", report.Failed())
+	}
+
+	err := report.Err()
+	var hsmErr *Error
+	if err == nil || !errors.As(err, &hsmErr) || !hsmErr.Retryable() {
+		t.Errorf("expected an error wrapping the retryable HSM error, got %v", err)
+	}
+	if (MemberReport{{Xname: "x0c0s1b0n0", Status: MemberUnchanged}}).Err() != nil {
+		t.Error("expected no error without failures")
+	}
+}
+
+func TestEditMembersStatus(t *testing.T) {
+	notFound := &Error{Op: "editing member", StatusCode: http.StatusNotFound}
+	conflict := &Error{Op: "editing member", StatusCode: http.StatusConflict}
+	// x1 is already in the partition; x2 is in another partition.
+	partition := func(ctx context.Context) ([]string, error) {
+		return []string{"x1"}, nil
+	}
+	for _, tc := range []struct {
+		op      string
+		err     error
+		xname   string
+		members func(ctx context.Context) ([]string, error)
+		status  string
+	}{
+		{MemberAdd, nil, "x2", partition, MemberChanged},
+		{MemberAdd, conflict, "x1", partition, MemberUnchanged},
+		{MemberAdd, conflict, "x2", partition, MemberFailed},
+		{MemberAdd, conflict, "x1", nil, MemberFailed},
+		{MemberAdd, notFound, "x2", partition, MemberFailed},
+		{MemberRemove, notFound, "x2", partition, MemberUnchanged},
+		{MemberRemove, conflict, "x1", partition, MemberFailed},
+	} {
+		report := EditMembers(context.Background(), tc.op, []string{tc.xname}, 1, func(ctx context.Context, xname string) error {
+			return tc.err
+		}, tc.members)
+		if report[0].Status != tc.status {
+			t.Errorf("%s %s returning %v: expected %s, got %+v", tc.op, tc.xname, tc.err, tc.status, report[0])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package hsm
+
+// MemberId is the payload used to add a single member to an HSM
+// group or partition.
+type MemberId struct {
+	Id string
+}
+
+type Ids struct {
+	Ids []string
+}
+
+type Partition struct {
+	Name        string
+	Description string
+	Tags        []string
+	Members     Ids
+}
+
+type Group struct {
+	Label          string
+	Description    string
+	ExclusiveGroup string
+	Tags           []string
+	Members        Ids
+}
+
+type Component struct {
+	ID                  string
+	Type                string
+	State               string
+	Flag                string
+	Enabled             bool
+	SoftwareStatus      string
+	Role                string
+	SubRole             string
+	NID                 int32
+	Subtype             string
+	NetType             string
+	Arch                string
+	Class               string
+	ReservationDisabled bool
+	Locked              bool
+}
+
+type ComponentList struct {
+	Components []Component
+}
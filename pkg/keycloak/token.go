@@ -0,0 +1,202 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package keycloak obtains and caches OIDC access tokens from Keycloak.
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/cray-tapms-operator/pkg/retry"
+)
+
+// Error is returned for any non-2xx response from Keycloak.
+type Error struct {
+	// Op describes the operation that failed, e.g. "listing groups".
+	Op string
+	// StatusCode is the HTTP status code returned by Keycloak.
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("keycloak returned a non-200 response %s: %d", e.Op, e.StatusCode)
+}
+
+// Retryable returns true if the Keycloak response indicates a transient failure.
+func (e *Error) Retryable() bool {
+	return retry.RetryableStatus(e.StatusCode)
+}
+
+// Credentials returns the form values for a token request, e.g.
+// client_id, grant_type and client_secret.
+type Credentials func(ctx context.Context) (url.Values, error)
+
+// refreshSkew is how long before it expires a token is refreshed, so it
+// does not expire while a request using it is in flight.
+const refreshSkew = 30 * time.Second
+
+// tokenResponse is the OIDC token endpoint response.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+// TokenCache returns access tokens for a single realm, requesting a new
+// token only when the cached one is about to expire. A refresh token is
+// used when Keycloak issued one, otherwise the credentials are exchanged
+// again. It is safe for concurrent use.
+type TokenCache struct {
+	tokenUrl    string
+	httpClient  *http.Client
+	credentials Credentials
+
+	mu             sync.Mutex
+	clientId       string
+	clientSecret   string
+	accessToken    string
+	expiresAt      time.Time
+	refreshToken   string
+	refreshExpires time.Time
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewTokenCache returns a TokenCache for the token endpoint at tokenUrl
+// (e.g. .../realms/shasta/protocol/openid-connect/token).
+func NewTokenCache(tokenUrl string, httpClient *http.Client, credentials Credentials) *TokenCache {
+	return &TokenCache{
+		tokenUrl:    tokenUrl,
+		httpClient:  httpClient,
+		credentials: credentials,
+		now:         time.Now,
+	}
+}
+
+// Token returns a valid access token.
+func (c *TokenCache) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.accessToken != "" && now.Before(c.expiresAt.Add(-refreshSkew)) {
+		return c.accessToken, nil
+	}
+
+	if c.refreshToken != "" && now.Before(c.refreshExpires.Add(-refreshSkew)) {
+		data := url.Values{}
+		data.Set("grant_type", "refresh_token")
+		data.Set("client_id", c.clientId)
+		data.Set("refresh_token", c.refreshToken)
+		if c.clientSecret != "" {
+			data.Set("client_secret", c.clientSecret)
+		}
+		if err := c.request(ctx, data); err == nil {
+			return c.accessToken, nil
+		}
+		// The refresh token may have been revoked, e.g. by a Keycloak
+		// restart, so fall back to the credentials.
+	}
+
+	data, err := c.credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := c.request(ctx, data); err != nil {
+		return "", err
+	}
+	return c.accessToken, nil
+}
+
+// Invalidate drops the cached tokens, e.g. when the credentials change.
+func (c *TokenCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+}
+
+func (c *TokenCache) clear() {
+	c.accessToken = ""
+	c.refreshToken = ""
+	c.expiresAt = time.Time{}
+	c.refreshExpires = time.Time{}
+}
+
+// request exchanges data for a token and caches the response.
+func (c *TokenCache) request(ctx context.Context, data url.Values) error {
+	c.clear()
+	encoded := data.Encode()
+	// Requesting another token has no other effect, so it is retried.
+	req, err := http.NewRequestWithContext(retry.WithIdempotent(ctx), http.MethodPost, c.tokenUrl, strings.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	requested := c.now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		return &Error{Op: "getting token", StatusCode: resp.StatusCode}
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("keycloak returned no access token")
+	}
+
+	// Expiry is measured from when the request was sent, so network
+	// delays only make the token be refreshed early.
+	c.clientId = data.Get("client_id")
+	c.clientSecret = data.Get("client_secret")
+	c.accessToken = token.AccessToken
+	c.expiresAt = requested.Add(time.Duration(token.ExpiresIn) * time.Second)
+	if token.RefreshToken != "" {
+		c.refreshToken = token.RefreshToken
+		c.refreshExpires = requested.Add(time.Duration(token.RefreshExpiresIn) * time.Second)
+	}
+	return nil
+}
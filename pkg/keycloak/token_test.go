@@ -0,0 +1,102 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTokenCache(t *testing.T) {
+	var grants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		grants = append(grants, grant)
+		if grant == "refresh_token" && r.PostForm.Get("refresh_token") == "revoked" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(tokenResponse{
+			AccessToken:      "access-" + grant,
+			ExpiresIn:        300,
+			RefreshToken:     "refresh",
+			RefreshExpiresIn: 1800,
+		})
+	}))
+	defer server.Close()
+
+	credentialReads := 0
+	cache := NewTokenCache(server.URL, server.Client(), func(ctx context.Context) (url.Values, error) {
+		credentialReads++
+		return url.Values{"grant_type": {"password"}, "client_id": {"admin-cli"}}, nil
+	})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	expect := func(token string, want []string) {
+		t.Helper()
+		got, err := cache.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != token {
+			t.Errorf("expected token %s, got %s", token, got)
+		}
+		if len(grants) != len(want) {
+			t.Fatalf("expected grants %v, got %v", want, grants)
+		}
+		for i := range grants {
+			if grants[i] != want[i] {
+				t.Fatalf("expected grants %v, got %v", want, grants)
+			}
+		}
+	}
+
+	expect("access-password", []string{"password"})
+	expect("access-password", []string{"password"})
+
+	// Refreshed shortly before it expires.
+	now = now.Add(280 * time.Second)
+	expect("access-refresh_token", []string{"password", "refresh_token"})
+
+	// A revoked refresh token falls back to the credentials.
+	now = now.Add(280 * time.Second)
+	cache.refreshToken = "revoked"
+	expect("access-password", []string{"password", "refresh_token", "refresh_token", "password"})
+
+	cache.Invalidate()
+	expect("access-password", []string{"password", "refresh_token", "refresh_token", "password", "password"})
+	if credentialReads != 3 {
+		t.Errorf("expected credentials to be read 3 times, got %d", credentialReads)
+	}
+}
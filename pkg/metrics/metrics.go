@@ -0,0 +1,131 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package metrics records Prometheus metrics for the requests TAPMS makes
+// to external services (HSM, Keycloak, Vault, PCS and tenant hooks).
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Services reported in the service label.
+const (
+	ServiceHsm      = "hsm"
+	ServiceKeycloak = "keycloak"
+	ServiceVault    = "vault"
+	ServicePcs      = "pcs"
+	ServiceHook     = "hook"
+)
+
+// The code label for requests that failed without a response.
+const codeError = "error"
+
+var (
+	requestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tapms_external_requests_total",
+			Help: "Number of requests to external services, by service, operation, method and status code",
+		},
+		[]string{"service", "operation", "method", "code"},
+	)
+
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tapms_external_request_duration_seconds",
+			Help:    "Duration of requests to external services, by service, operation and method",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "operation", "method"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestDuration)
+}
+
+type operationKey struct{}
+
+// WithOperation returns a context that labels requests made with it as op,
+// overriding the operation derived from the request.
+func WithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFunc derives the operation label from a request, e.g. from
+// its path. It should return a small, fixed set of values.
+type OperationFunc func(req *http.Request) string
+
+type transport struct {
+	service   string
+	operation OperationFunc
+	base      http.RoundTripper
+}
+
+// InstrumentClient returns a copy of client that records metrics for each
+// request under service. If operation is nil, requests without an
+// operation set by WithOperation are labeled "unknown".
+func InstrumentClient(client *http.Client, service string, operation OperationFunc) *http.Client {
+	instrumented := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	instrumented.Transport = &transport{
+		service:   service,
+		operation: operation,
+		base:      base,
+	}
+	return &instrumented
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, ok := req.Context().Value(operationKey{}).(string)
+	if !ok {
+		op = "unknown"
+		if t.operation != nil {
+			op = t.operation(req)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	requestDuration.WithLabelValues(t.service, op, req.Method).Observe(time.Since(start).Seconds())
+
+	code := codeError
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.WithLabelValues(t.service, op, req.Method, code).Inc()
+
+	return resp, err
+}
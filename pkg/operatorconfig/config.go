@@ -0,0 +1,273 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package operatorconfig loads the tapms operator config file, which
+// holds the location of, and how to trust, each backend service.
+package operatorconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the operator config file. Fields left out of the file keep
+// the values from Default.
+type Config struct {
+	// ApiGateway is the host of the API gateway, used for the default
+	// HSM, PCS and cluster Keycloak URLs.
+	ApiGateway string `json:"apiGateway"`
+	// ServerPort is the port of the tenant API. It is only read at
+	// startup.
+	ServerPort string   `json:"serverPort"`
+	Keycloak   Keycloak `json:"keycloak"`
+	Hsm        Hsm      `json:"hsm"`
+	Pcs        Service  `json:"pcs"`
+	Vault      Vault    `json:"vault"`
+}
+
+// Service is how a backend service is reached.
+type Service struct {
+	// URL is the base URL of the service.
+	URL string `json:"url"`
+	// CABundle is the path of a PEM file with the CA certificates
+	// trusted for the service. If empty, the system roots are used.
+	CABundle string `json:"caBundle,omitempty"`
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+	// Timeout limits each request to the service, 0 means no limit.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// SecretRef names a secret.
+type SecretRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Keycloak is how Keycloak is reached. Groups are managed in Realm with
+// a token for MasterRealm from URL, and requests through the API
+// gateway use a Realm token from GatewayURL.
+type Keycloak struct {
+	Service           `json:",inline"`
+	GatewayURL        string    `json:"gatewayUrl"`
+	Realm             string    `json:"realm"`
+	MasterRealm       string    `json:"masterRealm"`
+	MasterAdminSecret SecretRef `json:"masterAdminSecret"`
+	AdminClientSecret SecretRef `json:"adminClientSecret"`
+	// ClientID is the Realm client that tenant API tokens must be issued
+	// for, in their aud or azp claim.
+	ClientID string `json:"clientId"`
+}
+
+// Hsm is how HSM is reached.
+type Hsm struct {
+	Service `json:",inline"`
+	// MaxParallelRequests limits the requests made at once to add or
+	// remove group and partition members.
+	MaxParallelRequests int `json:"maxParallelRequests"`
+}
+
+// Vault is how Vault is reached. If URL is empty, VAULT_ADDR is used.
+type Vault struct {
+	Service `json:",inline"`
+	// Role is the Vault Kubernetes auth role tapms logs in with.
+	Role string `json:"role"`
+	// ServiceAccountTokenPath is the token used for Kubernetes auth.
+	ServiceAccountTokenPath string `json:"serviceAccountTokenPath"`
+}
+
+// Default returns the config used without a config file, taken from the
+// API_GATEWAY, SERVER_PORT, KEYCLOAK_URL, CLUSTER_KEYCLOAK_URL, HSM_URL,
+// PCS_URL and VAULT_ADDR environment variables where set.
+func Default() *Config {
+	apiGateway := getEnvVal("API_GATEWAY", "api-gw-service-nmn.local")
+	return &Config{
+		ApiGateway: apiGateway,
+		ServerPort: getEnvVal("SERVER_PORT", "80"),
+		Keycloak: Keycloak{
+			Service:           insecureService(getEnvVal("KEYCLOAK_URL", "http://keycloak.services:8080/keycloak")),
+			GatewayURL:        getEnvVal("CLUSTER_KEYCLOAK_URL", fmt.Sprintf("https://%s/keycloak", apiGateway)),
+			Realm:             "shasta",
+			MasterRealm:       "master",
+			MasterAdminSecret: SecretRef{Namespace: "services", Name: "keycloak-master-admin-auth"},
+			AdminClientSecret: SecretRef{Namespace: "default", Name: "admin-client-auth"},
+			ClientID:          "shasta",
+		},
+		Hsm: Hsm{
+			Service:             insecureService(getEnvVal("HSM_URL", fmt.Sprintf("https://%s/apis/smd", apiGateway))),
+			MaxParallelRequests: 10,
+		},
+		Pcs: insecureService(getEnvVal("PCS_URL", fmt.Sprintf("https://%s/apis/power-control", apiGateway))),
+		Vault: Vault{
+			Service:                 Service{URL: os.Getenv("VAULT_ADDR")},
+			Role:                    "tapms-operator",
+			ServiceAccountTokenPath: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		},
+	}
+}
+
+// Certificates of services other than Vault are not verified by default,
+// as before the config file existed. Set a caBundle and
+// insecureSkipVerify: false to verify them.
+func insecureService(url string) Service {
+	return Service{URL: url, InsecureSkipVerify: true}
+}
+
+// Load reads and validates the config file at path. If path is empty,
+// the default config is returned.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, cfg.Validate()
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error for the first invalid field.
+func (c *Config) Validate() error {
+	if c.ServerPort == "" {
+		return fmt.Errorf("serverPort is required")
+	}
+	if err := c.Keycloak.Service.validate("keycloak", true); err != nil {
+		return err
+	}
+	if err := validateUrl("keycloak.gatewayUrl", c.Keycloak.GatewayURL); err != nil {
+		return err
+	}
+	if c.Keycloak.Realm == "" || c.Keycloak.MasterRealm == "" {
+		return fmt.Errorf("keycloak.realm and keycloak.masterRealm are required")
+	}
+	if c.Keycloak.ClientID == "" {
+		return fmt.Errorf("keycloak.clientId is required")
+	}
+	for field, ref := range map[string]SecretRef{
+		"keycloak.masterAdminSecret": c.Keycloak.MasterAdminSecret,
+		"keycloak.adminClientSecret": c.Keycloak.AdminClientSecret,
+	} {
+		if ref.Namespace == "" || ref.Name == "" {
+			return fmt.Errorf("%s requires a namespace and name", field)
+		}
+	}
+	if err := c.Hsm.Service.validate("hsm", true); err != nil {
+		return err
+	}
+	if c.Hsm.MaxParallelRequests < 1 {
+		return fmt.Errorf("hsm.maxParallelRequests must be at least 1")
+	}
+	if err := c.Pcs.validate("pcs", true); err != nil {
+		return err
+	}
+	if err := c.Vault.Service.validate("vault", false); err != nil {
+		return err
+	}
+	if c.Vault.Role == "" {
+		return fmt.Errorf("vault.role is required")
+	}
+	return nil
+}
+
+func (s *Service) validate(field string, required bool) error {
+	if s.URL != "" || required {
+		if err := validateUrl(field+".url", s.URL); err != nil {
+			return err
+		}
+	}
+	if s.Timeout.Duration < 0 {
+		return fmt.Errorf("%s.timeout must not be negative", field)
+	}
+	if s.CABundle != "" {
+		if _, err := s.certPool(); err != nil {
+			return fmt.Errorf("%s.caBundle: %w", field, err)
+		}
+	}
+	return nil
+}
+
+func validateUrl(field string, value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL, got %q", field, value)
+	}
+	return nil
+}
+
+func (s *Service) certPool() (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(s.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", s.CABundle)
+	}
+	return pool, nil
+}
+
+// TLSConfig returns the TLS config for requests to the service.
+func (s *Service) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: s.InsecureSkipVerify}
+	if s.CABundle != "" {
+		pool, err := s.certPool()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// NamespacedName returns the name of the secret.
+func (r SecretRef) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+}
+
+func getEnvVal(envVar, defVal string) string {
+	if e, ok := os.LookupEnv(envVar); ok {
+		return e
+	}
+	return defVal
+}
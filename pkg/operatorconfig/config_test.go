@@ -0,0 +1,167 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package operatorconfig
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func writeFile(t *testing.T, path string, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caBundle := filepath.Join(dir, "ca.pem")
+	writeFile(t, caBundle, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})))
+	path := filepath.Join(dir, "config.yaml")
+	writeFile(t, path, `
+hsm:
+  url: `+server.URL+`
+  caBundle: `+caBundle+`
+  insecureSkipVerify: false
+  timeout: 5s
+keycloak:
+  realm: tenants
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Hsm.Timeout.Duration != 5*time.Second || cfg.Keycloak.Realm != "tenants" {
+		t.Errorf("expected the file to override the defaults, got %+v", cfg)
+	}
+	if cfg.Keycloak.MasterRealm != "master" || cfg.Pcs.URL != Default().Pcs.URL {
+		t.Errorf("expected fields not in the file to keep their defaults, got %+v", cfg)
+	}
+
+	tlsConfig, err := cfg.Hsm.TLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %v", err)
+	}
+	resp.Body.Close()
+
+	for data, want := range map[string]string{
+		"hsm:\n  url: smd\n":                                     "hsm.url",
+		"pcs:\n  caBundle: /missing.pem\n":                       "pcs.caBundle",
+		"keycloak:\n  masterAdminSecret:\n    namespace: \"\"\n": "keycloak.masterAdminSecret",
+		"hsm:\n  maxParallelRequests: 0\n":                       "hsm.maxParallelRequests",
+		"keycloak:\n  clientId: \"\"\n":                          "keycloak.clientId",
+		"vault:\n  role: \"\"\n":                                 "vault.role",
+		"unknown: true\n":                                        "unknown",
+	} {
+		writeFile(t, path, data)
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected an error mentioning %s for %q, got %v", want, data, err)
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "keycloak:\n  realm: shasta\n")
+	current, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := make(chan *Config, 10)
+	w := &Watcher{Path: path, Current: current, Log: logr.Discard(), OnChange: func(cfg *Config) error { changed <- cfg; return nil }}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	// The watcher may not have started yet, so the change is written again
+	// until it is noticed. An invalid config is ignored.
+	timeout := time.After(5 * time.Second)
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		writeFile(t, path, "keycloak:\n  realm: \"\"\n")
+		writeFile(t, path, "keycloak:\n  realm: tenants\n")
+		select {
+		case cfg := <-changed:
+			if cfg.Keycloak.Realm != "tenants" {
+				t.Errorf("expected the changed realm, got %s", cfg.Keycloak.Realm)
+			}
+			return
+		case <-ticker.C:
+		case <-timeout:
+			t.Fatal("expected the config change to be noticed")
+		}
+	}
+}
+
+func TestWatcherApplyFailed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "keycloak:\n  realm: shasta\n")
+	current, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applyErr := errors.New("apply failed")
+	applied := 0
+	w := &Watcher{Path: path, Current: current, Log: logr.Discard(), OnChange: func(cfg *Config) error {
+		applied++
+		return applyErr
+	}}
+	writeFile(t, path, "keycloak:\n  realm: tenants\n")
+	w.reload()
+	if applied != 1 || w.Current != current {
+		t.Errorf("expected a config that failed to apply not to be made current, got %d calls and realm %s", applied, w.Current.Keycloak.Realm)
+	}
+
+	// The same config is applied again on the next event.
+	applyErr = nil
+	w.reload()
+	if applied != 2 || w.Current.Keycloak.Realm != "tenants" {
+		t.Errorf("expected the config to be applied again and made current, got %d calls and realm %s", applied, w.Current.Keycloak.Realm)
+	}
+}
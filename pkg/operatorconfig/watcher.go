@@ -0,0 +1,97 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package operatorconfig
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+)
+
+// Watcher reloads the config file when it changes. It is run by the
+// manager.
+type Watcher struct {
+	// Path is the config file. Its directory is watched, since a mounted
+	// ConfigMap is updated by replacing a symlink.
+	Path string
+	// Current is the config last applied, starting with the one loaded at
+	// startup.
+	Current *Config
+	// OnChange is called with each changed, valid config. An invalid
+	// config, or one OnChange returns an error for, is logged and not made
+	// current, so it is applied again on the next change to the file.
+	OnChange func(*Config) error
+	Log      logr.Logger
+}
+
+// NeedLeaderElection returns false, since every replica uses the config.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the config file until ctx is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(w.Path)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watcher.Errors:
+			w.Log.Error(err, "Error watching config file", "path", w.Path)
+		case <-watcher.Events:
+			w.reload()
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	cfg, err := Load(w.Path)
+	if err != nil {
+		w.Log.Error(err, "Ignoring invalid config file", "path", w.Path)
+		return
+	}
+	if reflect.DeepEqual(cfg, w.Current) {
+		return
+	}
+	w.Log.Info("Config file changed", "path", w.Path)
+	if err := w.OnChange(cfg); err != nil {
+		w.Log.Error(err, "Unable to apply config file", "path", w.Path)
+		return
+	}
+	w.Current = cfg
+}
@@ -0,0 +1,146 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package pcs is a client for the Power Control Service APIs used by
+// TAPMS to power off nodes that move between tenants.
+package pcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Client is the set of PCS operations used by TAPMS.
+type Client interface {
+	GetPowerStatus(ctx context.Context, xnames []string) (*PowerStatus, error)
+	CreateTransition(ctx context.Context, operation string, xnames []string) (*TransitionStartOutput, error)
+	GetTransition(ctx context.Context, transitionID string) (*Transition, error)
+}
+
+// TokenSource returns a bearer token to use for a single PCS request.
+type TokenSource func(ctx context.Context) (string, error)
+
+type client struct {
+	baseUrl    string
+	httpClient *http.Client
+	token      TokenSource
+}
+
+// NewClient returns a Client for the power-control service at baseUrl
+// (e.g. https://api-gw-service-nmn.local/apis/power-control). The same
+// httpClient is used for every request.
+func NewClient(baseUrl string, httpClient *http.Client, token TokenSource) Client {
+	return &client{
+		baseUrl:    baseUrl,
+		httpClient: httpClient,
+		token:      token,
+	}
+}
+
+func (c *client) GetPowerStatus(ctx context.Context, xnames []string) (*PowerStatus, error) {
+	query := url.Values{}
+	for _, xname := range xnames {
+		query.Add("xname", xname)
+	}
+	status := PowerStatus{}
+	err := c.do(ctx, http.MethodGet, "/v1/power-status?"+query.Encode(), nil, &status, "getting power status")
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (c *client) CreateTransition(ctx context.Context, operation string, xnames []string) (*TransitionStartOutput, error) {
+	request := TransitionRequest{Operation: operation}
+	for _, xname := range xnames {
+		request.Location = append(request.Location, TransitionLocation{Xname: xname})
+	}
+	output := TransitionStartOutput{}
+	err := c.do(ctx, http.MethodPost, "/v1/transitions", request, &output, "requesting power transition")
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func (c *client) GetTransition(ctx context.Context, transitionID string) (*Transition, error) {
+	path := fmt.Sprintf("/v1/transitions/%s", url.PathEscape(transitionID))
+	transition := Transition{}
+	err := c.do(ctx, http.MethodGet, path, nil, &transition, fmt.Sprintf("getting power transition %s", transitionID))
+	if err != nil {
+		return nil, err
+	}
+	return &transition, nil
+}
+
+// do issues a single request against PCS. The payload (if any) is sent
+// as JSON, and a 2xx response body is decoded into out (if not nil).
+// Any other response is returned as an *Error.
+func (c *client) do(ctx context.Context, method string, path string, payload interface{}, out interface{}, op string) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		return &Error{Op: op, StatusCode: resp.StatusCode}
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,101 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package pcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, server.Client(), func(ctx context.Context) (string, error) {
+		return "token", nil
+	})
+}
+
+func TestCreateTransition(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/transitions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var request TransitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+		if request.Operation != OperationForceOff || len(request.Location) != 2 || request.Location[1].Xname != "x0c3s6b0n0" {
+			t.Errorf("unexpected transition request %+v", request)
+		}
+		json.NewEncoder(w).Encode(TransitionStartOutput{TransitionID: "8a2b", Operation: request.Operation})
+	})
+
+	output, err := c.CreateTransition(context.Background(), OperationForceOff, []string{"x0c3s5b0n0", "x0c3s6b0n0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.TransitionID != "8a2b" {
+		t.Errorf("unexpected transition ID %q", output.TransitionID)
+	}
+}
+
+func TestGetTransition(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/transitions/8a2b" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"transitionID":"8a2b","operation":"off","transitionStatus":"completed",
+			"taskCounts":{"total":2,"failed":1,"succeeded":1},
+			"tasks":[{"xname":"x0c3s5b0n0","taskStatus":"succeeded"},{"xname":"x0c3s6b0n0","taskStatus":"failed","error":"no power"}]}`))
+	})
+
+	transition, err := c.GetTransition(context.Background(), "8a2b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !transition.Done() || transition.TaskCounts.Failed != 1 {
+		t.Errorf("unexpected transition %+v", transition)
+	}
+	if !reflect.DeepEqual(transition.FailedXnames(), []string{"x0c3s6b0n0"}) {
+		t.Errorf("unexpected failed xnames %v", transition.FailedXnames())
+	}
+}
+
+func TestGetTransitionNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := c.GetTransition(context.Background(), "8a2b")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
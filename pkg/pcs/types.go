@@ -0,0 +1,120 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package pcs
+
+// Power transition operations.
+const (
+	OperationOff      = "off"
+	OperationForceOff = "force-off"
+)
+
+// Transition statuses reported by PCS.
+const (
+	TransitionStatusNew           = "new"
+	TransitionStatusInProgress    = "in-progress"
+	TransitionStatusCompleted     = "completed"
+	TransitionStatusAborted       = "aborted"
+	TransitionStatusAbortSignaled = "abort-signaled"
+)
+
+// Task statuses reported by PCS for a single xname in a transition.
+const (
+	TaskStatusFailed      = "failed"
+	TaskStatusSucceeded   = "succeeded"
+	TaskStatusUnsupported = "unsupported"
+)
+
+type XnamePowerState struct {
+	Xname                     string   `json:"xname,omitempty"`
+	PowerState                string   `json:"powerState,omitempty"`
+	ManagementState           string   `json:"managementState,omitempty"`
+	Error                     string   `json:"error,omitempty"`
+	LastUpdated               string   `json:"lastUpdated,omitempty"`
+	SupportedPowerTransitions []string `json:"supportedPowerTransitions,omitempty"`
+}
+
+type PowerStatus struct {
+	Status []XnamePowerState `json:"status,omitempty"`
+}
+
+type TransitionLocation struct {
+	Xname     string `json:"xname,omitempty"`
+	DeputyKey string `json:"deputyKey,omitempty"`
+}
+
+type TransitionRequest struct {
+	Operation           string               `json:"operation,omitempty"`
+	TaskDeadlineMinutes int                  `json:"taskDeadlineMinutes"`
+	Location            []TransitionLocation `json:"location,omitempty"`
+}
+
+type TransitionStartOutput struct {
+	TransitionID string `json:"transitionID,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+}
+
+type TaskCounts struct {
+	Total       int `json:"total"`
+	New         int `json:"new"`
+	InProgress  int `json:"in-progress"`
+	Failed      int `json:"failed"`
+	Succeeded   int `json:"succeeded"`
+	Unsupported int `json:"un-supported"`
+}
+
+type TransitionTask struct {
+	Xname                 string `json:"xname,omitempty"`
+	TaskStatus            string `json:"taskStatus,omitempty"`
+	TaskStatusDescription string `json:"taskStatusDescription,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// Transition is the state of a power transition as returned by
+// GET /transitions/{transitionID}.
+type Transition struct {
+	TransitionID     string           `json:"transitionID,omitempty"`
+	Operation        string           `json:"operation,omitempty"`
+	TransitionStatus string           `json:"transitionStatus,omitempty"`
+	TaskCounts       TaskCounts       `json:"taskCounts,omitempty"`
+	Tasks            []TransitionTask `json:"tasks,omitempty"`
+}
+
+// Done returns true once PCS is no longer working on the transition.
+func (t *Transition) Done() bool {
+	return t.TransitionStatus == TransitionStatusCompleted || t.TransitionStatus == TransitionStatusAborted
+}
+
+// FailedXnames returns the xnames whose task in the transition failed.
+func (t *Transition) FailedXnames() []string {
+	var xnames []string
+	for _, task := range t.Tasks {
+		if task.TaskStatus == TaskStatusFailed {
+			xnames = append(xnames, task.Xname)
+		}
+	}
+	return xnames
+}
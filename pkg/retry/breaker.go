@@ -0,0 +1,122 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package retry
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OpenError is returned instead of calling a backend whose circuit is open.
+type OpenError struct {
+	// Name is the backend, e.g. "hsm".
+	Name string
+	// RetryAfter is how long until the circuit allows another attempt.
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s is unavailable, retrying in %s", e.Name, e.RetryAfter.Round(time.Second))
+}
+
+// Retryable marks an open circuit as a transient failure.
+func (e *OpenError) Retryable() bool {
+	return true
+}
+
+// Breaker is a circuit breaker for a single backend. After Threshold
+// consecutive retryable failures the circuit opens, and calls fail fast
+// with an *OpenError for OpenDuration. After that a single call is let
+// through: success closes the circuit, failure opens it again.
+type Breaker struct {
+	Name         string
+	Threshold    int
+	OpenDuration time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewBreaker returns a closed circuit breaker for the named backend.
+func NewBreaker(name string, threshold int, openDuration time.Duration) *Breaker {
+	return &Breaker{
+		Name:         name,
+		Threshold:    threshold,
+		OpenDuration: openDuration,
+		now:          time.Now,
+	}
+}
+
+// Allow returns an *OpenError if the circuit is open, or nil if a call
+// may be made.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.Threshold {
+		return nil
+	}
+	remaining := b.OpenDuration - b.clock().Sub(b.openedAt)
+	if remaining > 0 || b.probing {
+		if remaining <= 0 {
+			remaining = b.OpenDuration
+		}
+		return &OpenError{Name: b.Name, RetryAfter: remaining}
+	}
+	b.probing = true
+	return nil
+}
+
+// Record updates the circuit with the outcome of a call. Only retryable
+// errors count as failures; a fatal error means the backend is up.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !IsRetryable(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.Threshold {
+		b.openedAt = b.clock()
+	}
+}
+
+func (b *Breaker) clock() time.Time {
+	if b.now == nil {
+		return time.Now()
+	}
+	return b.now()
+}
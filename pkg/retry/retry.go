@@ -0,0 +1,150 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package retry provides the retry policy shared by the clients TAPMS
+// uses to call backend services through the API gateway: exponential
+// backoff with jitter, classification of retryable errors, and a circuit
+// breaker per backend.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Backoff describes how long to wait between attempts.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay between attempts.
+	Max time.Duration
+	// Multiplier is applied to the delay after each attempt.
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction of it.
+	Jitter float64
+	// Steps is the maximum number of attempts, including the first.
+	Steps int
+}
+
+// DefaultBackoff is short enough not to hold up a reconcile worker for long.
+var DefaultBackoff = Backoff{
+	Initial:    250 * time.Millisecond,
+	Max:        4 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+	Steps:      4,
+}
+
+// Delay returns the delay after the given attempt, counting from 1.
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.Initial) * math.Pow(b.Multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// RetryableStatus returns true for HTTP status codes that indicate a
+// transient failure of the backend or the API gateway in front of it.
+func RetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsRetryable returns true if err is transient, so the operation may
+// succeed if tried again later. Errors may declare themselves retryable
+// with a Retryable() bool method; network errors are retryable, as is an
+// open circuit. Anything else is considered fatal.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Do calls fn until it succeeds, returns a fatal error, or the backoff
+// runs out of steps. If breaker is not nil, each attempt is recorded by
+// it, and no attempt is made while it is open.
+func Do(ctx context.Context, backoff Backoff, breaker *Breaker, fn func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if breaker != nil {
+			if err = breaker.Allow(); err != nil {
+				return err
+			}
+		}
+		err = fn()
+		if breaker != nil {
+			breaker.Record(err)
+		}
+		if err == nil || !IsRetryable(err) || attempt >= backoff.Steps {
+			return err
+		}
+		if err = sleep(ctx, backoff.Delay(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testBackoff = Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Multiplier: 2, Steps: 3}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, Jitter: 0.2}
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		delay := b.Delay(attempt)
+		if delay < expected*8/10 || delay > expected*12/10 {
+			t.Errorf("attempt %d: expected about %s, got %s", attempt, expected, delay)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), testBackoff, nil, func() error {
+		calls++
+		return &statusError{code: http.StatusServiceUnavailable}
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("expected 3 calls and an error, got %d and %v", calls, err)
+	}
+
+	calls = 0
+	err = Do(context.Background(), testBackoff, nil, func() error {
+		calls++
+		return errors.New("fatal")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected 1 call and an error, got %d and %v", calls, err)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker("hsm", 2, time.Minute)
+	b.now = func() time.Time { return now }
+	transient := &statusError{code: http.StatusBadGateway}
+
+	b.Record(transient)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected closed circuit after one failure, got %v", err)
+	}
+	b.Record(transient)
+	var open *OpenError
+	if err := b.Allow(); !errors.As(err, &open) || !IsRetryable(err) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a probe to be allowed, got %v", err)
+	}
+	if err := b.Allow(); err == nil {
+		t.Fatal("expected only one probe to be allowed")
+	}
+	b.Record(nil)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected closed circuit after a successful probe, got %v", err)
+	}
+}
+
+func TestWrapClient(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/flaky":
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := WrapClient(server.Client(), testBackoff, NewBreaker("test", 10, time.Minute))
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/flaky", strings.NewReader("{}"))
+	resp, err := client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("expected success on the third call, got %v, %v after %d calls", resp, err, calls)
+	}
+
+	calls = 0
+	resp, err = client.Post(server.URL+"/flaky", "application/json", strings.NewReader("{}"))
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("expected a POST not to be retried, got %v, %v after %d calls", resp, err, calls)
+	}
+
+	calls = 0
+	req, _ = http.NewRequestWithContext(WithIdempotent(context.Background()), http.MethodPost, server.URL+"/flaky", strings.NewReader("{}"))
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("expected an idempotent POST to succeed on the third call, got %v, %v after %d calls", resp, err, calls)
+	}
+
+	calls = 0
+	resp, err = client.Get(server.URL + "/missing")
+	if err != nil || resp.StatusCode != http.StatusNotFound || calls != 1 {
+		t.Errorf("expected a single 404, got %v, %v after %d calls", resp, err, calls)
+	}
+}
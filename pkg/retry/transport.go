@@ -0,0 +1,157 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// statusError records a retryable response, so the breaker counts it.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+func (e *statusError) Retryable() bool {
+	return RetryableStatus(e.code)
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a copy of ctx marking the requests made with it
+// as safe to retry, for POSTs that have no further effect when repeated,
+// such as adding a group member.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// replayable returns true if req may be sent again: its method is
+// idempotent or it was made with WithIdempotent, and its body can be
+// replayed.
+func replayable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+type transport struct {
+	base    http.RoundTripper
+	backoff Backoff
+	breaker *Breaker
+}
+
+// WrapClient returns a copy of client that retries requests failing with
+// a retryable error or status code, and fails fast while breaker (if not
+// nil) is open. Only idempotent requests are retried, see replayable, so
+// a POST that may have taken effect before failing is not sent twice. The
+// last response is returned as is, so callers still see the status.
+func WrapClient(client *http.Client, backoff Backoff, breaker *Breaker) *http.Client {
+	wrapped := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped.Transport = &transport{
+		base:    base,
+		backoff: backoff,
+		breaker: breaker,
+	}
+	return &wrapped
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !replayable(req) {
+		if t.breaker != nil {
+			if err := t.breaker.Allow(); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := t.base.RoundTrip(req)
+		if t.breaker != nil {
+			t.breaker.Record(outcome(resp, err))
+		}
+		return resp, err
+	}
+
+	var resp *http.Response
+	err := Do(req.Context(), t.backoff, t.breaker, func() error {
+		if resp != nil {
+			// Drain the previous response so the connection can be reused.
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+			resp = nil
+		}
+
+		attempt := req
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		var err error
+		resp, err = t.base.RoundTrip(attempt)
+		return outcome(resp, err)
+	})
+
+	var status *statusError
+	if err == nil || (errors.As(err, &status) && resp != nil) {
+		return resp, nil
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return nil, err
+}
+
+// outcome returns the error to record for a round trip.
+func outcome(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if RetryableStatus(resp.StatusCode) {
+		return &statusError{code: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package xname matches xnames against patterns with numeric ranges, e.g.
+// x1000c[0-3]s[0-7]b[0-1]n[0-1], so tenants can claim whole racks or
+// chassis without listing every node.
+package xname
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDigits bounds the numbers in xnames and ranges, so they fit an int.
+const maxDigits = 9
+
+// Range is an inclusive range of numbers.
+type Range struct {
+	Min int
+	Max int
+}
+
+// Ranges is a set of numbers, e.g. parsed from 0-3,8,10-11.
+type Ranges []Range
+
+// Contains returns true if n is in one of the ranges.
+func (r Ranges) Contains(n int) bool {
+	for _, rng := range r {
+		if n >= rng.Min && n <= rng.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRanges parses a comma separated list of numbers and ranges, e.g.
+// 0-3,8,10-11.
+func ParseRanges(s string) (Ranges, error) {
+	var ranges Ranges
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		bounds := strings.SplitN(item, "-", 2)
+		min, err := parseNumber(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: %w", item, err)
+		}
+		max := min
+		if len(bounds) == 2 {
+			max, err = parseNumber(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q: %w", item, err)
+			}
+		}
+		if max < min {
+			return nil, fmt.Errorf("invalid range %q: %d is less than %d", item, max, min)
+		}
+		ranges = append(ranges, Range{Min: min, Max: max})
+	}
+	return ranges, nil
+}
+
+func parseNumber(s string) (int, error) {
+	if s == "" || len(s) > maxDigits || strings.Trim(s, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// part is literal text, or the ranges of a bracketed list matching one
+// number.
+type part struct {
+	literal string
+	ranges  Ranges
+}
+
+// Pattern is a parsed xname pattern.
+type Pattern struct {
+	text  string
+	parts []part
+}
+
+// ParsePattern parses an xname pattern. Each bracketed list of numbers
+// and ranges matches one number in the xname, e.g. c[0-3] matches c0 to
+// c3. A pattern without brackets matches only itself.
+func ParsePattern(s string) (*Pattern, error) {
+	p := &Pattern{text: s}
+	rest := s
+	for rest != "" {
+		open := strings.IndexAny(rest, "[]")
+		if open < 0 {
+			p.parts = append(p.parts, part{literal: rest})
+			break
+		}
+		if rest[open] == ']' {
+			return nil, fmt.Errorf("invalid xname pattern %q: unexpected ]", s)
+		}
+		if open > 0 {
+			p.parts = append(p.parts, part{literal: rest[:open]})
+		}
+		end := strings.Index(rest[open:], "]")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid xname pattern %q: missing ]", s)
+		}
+		ranges, err := ParseRanges(rest[open+1 : open+end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid xname pattern %q: %w", s, err)
+		}
+		p.parts = append(p.parts, part{ranges: ranges})
+		rest = rest[open+end+1:]
+	}
+	if len(p.parts) == 0 {
+		return nil, fmt.Errorf("invalid xname pattern %q: empty", s)
+	}
+	return p, nil
+}
+
+func (p *Pattern) String() string {
+	return p.text
+}
+
+// Match returns true if xname matches the pattern. Bracketed ranges match
+// a whole number, without leading zeros.
+func (p *Pattern) Match(xname string) bool {
+	rest := xname
+	for _, part := range p.parts {
+		if part.ranges == nil {
+			if !strings.HasPrefix(rest, part.literal) {
+				return false
+			}
+			rest = rest[len(part.literal):]
+			continue
+		}
+		digits := len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+		if digits == 0 || (digits > 1 && rest[0] == '0') {
+			return false
+		}
+		n, err := parseNumber(rest[:digits])
+		if err != nil || !part.ranges.Contains(n) {
+			return false
+		}
+		rest = rest[digits:]
+	}
+	return rest == ""
+}
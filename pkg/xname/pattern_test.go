@@ -0,0 +1,94 @@
+/*
+ *
+ *  MIT License
+ *
+ *  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a
+ *  copy of this software and associated documentation files (the "Software"),
+ *  to deal in the Software without restriction, including without limitation
+ *  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ *  and/or sell copies of the Software, and to permit persons to whom the
+ *  Software is furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included
+ *  in all copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ *  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ *  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ *  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ *  OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package xname
+
+import (
+	"testing"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{
+			pattern: "x1000c[0-3]s[0-7]b[0-1]n[0-1]",
+			match:   []string{"x1000c0s0b0n0", "x1000c3s7b1n1", "x1000c2s5b0n1"},
+			noMatch: []string{"x1000c4s0b0n0", "x1000c0s8b0n0", "x1000c0s0b0n2", "x1001c0s0b0n0", "x1000c0s0b0n0x", "x1000c00s0b0n0", "x1000c0s0b0"},
+		},
+		{
+			pattern: "x3000c0s[1,3,5-6]b0n0",
+			match:   []string{"x3000c0s1b0n0", "x3000c0s3b0n0", "x3000c0s6b0n0"},
+			noMatch: []string{"x3000c0s2b0n0", "x3000c0s7b0n0"},
+		},
+		{
+			pattern: "x3000c0s[10-12]b0n0",
+			match:   []string{"x3000c0s10b0n0", "x3000c0s12b0n0"},
+			noMatch: []string{"x3000c0s1b0n0", "x3000c0s13b0n0"},
+		},
+		{
+			pattern: "x3000c0s19b0n0",
+			match:   []string{"x3000c0s19b0n0"},
+			noMatch: []string{"x3000c0s19b0n1"},
+		},
+	}
+	for _, test := range tests {
+		p, err := ParsePattern(test.pattern)
+		if err != nil {
+			t.Fatalf("%s: %v", test.pattern, err)
+		}
+		for _, xname := range test.match {
+			if !p.Match(xname) {
+				t.Errorf("expected %s to match %s", test.pattern, xname)
+			}
+		}
+		for _, xname := range test.noMatch {
+			if p.Match(xname) {
+				t.Errorf("expected %s not to match %s", test.pattern, xname)
+			}
+		}
+	}
+
+	for _, pattern := range []string{"", "x1000c[0-3", "x1000c0-3]", "x1000c[]", "x1000c[3-0]", "x1000c[a]", "x1000c[0-1234567890]"} {
+		if _, err := ParsePattern(pattern); err == nil {
+			t.Errorf("expected %q to be invalid", pattern)
+		}
+	}
+}
+
+func TestParseRanges(t *testing.T) {
+	ranges, err := ParseRanges("1-128, 256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, expected := range map[int]bool{0: false, 1: true, 128: true, 129: false, 256: true} {
+		if ranges.Contains(n) != expected {
+			t.Errorf("expected Contains(%d) to be %t", n, expected)
+		}
+	}
+}